@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/handlers"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/commands/validation"
+	"bot/types"
+)
+
+func absenceInteractionCommands(db *gorm.DB) types.Commands[types.InteractionHandler] {
+	handler := handlers.NewAbsenceHandler(
+		repos.NewAbsencesRepo(db),
+		repos.NewPlayersRepo(db),
+	)
+
+	return types.Commands[types.InteractionHandler]{{
+		Handler: types.InteractionHandler{
+			Main:         handler.Absence,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "absence",
+			Description:  "Verwaltet deine Abwesenheiten.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        handlers.AbsenceAddSubcommand,
+					Description: "Trägt eine Abwesenheit für einen oder alle deine Accounts ein.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        handlers.EndsAtOptionName,
+							Description: "Letzter Tag der Abwesenheit (DD.MM.YYYY).",
+							Required:    true,
+							MinLength:   util.IntPtr(8),
+							MaxLength:   10,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        handlers.ReasonOptionName,
+							Description: "Grund der Abwesenheit.",
+							Required:    true,
+							MinLength:   util.IntPtr(3),
+							MaxLength:   200,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        handlers.StartsAtOptionName,
+							Description: "Erster Tag der Abwesenheit (DD.MM.YYYY). Ohne Angabe ab heute.",
+							MinLength:   util.IntPtr(8),
+							MaxLength:   10,
+						},
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         handlers.MyPlayerTagOptionName,
+							Description:  "Account, der abwesend ist. Ohne Angabe gilt die Abwesenheit für alle deine Accounts.",
+							MinLength:    util.IntPtr(validation.TagMinLength),
+							MaxLength:    validation.TagMaxLength,
+							Autocomplete: true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        handlers.AbsenceListSubcommand,
+					Description: "Zeigt deine aktuellen und geplanten Abwesenheiten an.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        handlers.AbsenceRemoveSubcommand,
+					Description: "Entfernt eine deiner Abwesenheiten.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        handlers.IDOptionName,
+							Description: "ID der Abwesenheit, die entfernt werden soll.",
+							Required:    true,
+							MinValue:    util.FloatPtr(1),
+						},
+					},
+				},
+			},
+		},
+	}}
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/handlers"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/types"
+)
+
+func blacklistInteractionCommands(db *gorm.DB) types.Commands[types.InteractionHandler] {
+	handler := handlers.NewBlacklistHandler(
+		repos.NewBlacklistRepo(db),
+		repos.NewPlayersRepo(db),
+		repos.NewMembersRepo(db),
+		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
+	)
+
+	return types.Commands[types.InteractionHandler]{{
+		Handler: types.InteractionHandler{
+			Main:         handler.Blacklist,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "blacklist",
+			Description:  "Verwaltet die Blacklist der Family.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        handlers.BlacklistAddSubcommand,
+					Description: "Setzt einen Spieler auf die Blacklist.",
+					Options: []*discordgo.ApplicationCommandOption{
+						optionPlayerTag("Spieler, der auf die Blacklist gesetzt werden soll."),
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        handlers.ReasonOptionName,
+							Description: "Grund für den Eintrag.",
+							Required:    true,
+							MinLength:   util.IntPtr(3),
+							MaxLength:   200,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        handlers.ExpiresAtOptionName,
+							Description: "Datum, an dem der Eintrag abläuft (DD.MM.YYYY). Ohne Angabe läuft er nie ab.",
+							MinLength:   util.IntPtr(8),
+							MaxLength:   10,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        handlers.BlacklistListSubcommand,
+					Description: "Zeigt alle aktiven Einträge der Blacklist an.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        handlers.BlacklistRemoveSubcommand,
+					Description: "Entfernt einen Spieler von der Blacklist.",
+					Options: []*discordgo.ApplicationCommandOption{
+						optionPlayerTag("Spieler, der von der Blacklist entfernt werden soll."),
+					},
+				},
+			},
+		},
+	}}
+}
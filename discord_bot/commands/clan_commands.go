@@ -9,6 +9,7 @@ import (
 	"bot/commands/middleware"
 	"bot/commands/repos"
 	"bot/commands/util"
+	"bot/commands/validation"
 	"bot/types"
 )
 
@@ -16,7 +17,14 @@ func clanInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.Com
 	handler := handlers.NewClanHandler(
 		repos.NewClansRepo(db),
 		repos.NewMembersRepo(db),
+		repos.NewPlayersRepo(db),
+		repos.NewClanSettingsRepo(db),
 		repos.NewClanEventsRepo(db),
+		repos.NewAbsencesRepo(db),
+		repos.NewCWDonorsRepo(db),
+		repos.NewRaidsRepo(db),
+		repos.NewClanGamesRepo(db),
+		repos.NewDonationsRepo(db),
 		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
 		clashClient,
 	)
@@ -46,10 +54,11 @@ func clanInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.Com
 			Handler: types.InteractionHandler{
 				Main:         handler.CWDonator,
 				Autocomplete: handler.HandleAutocomplete,
+				Component:    handler.CWDonatorComponent,
 			},
 			ApplicationCommand: &discordgo.ApplicationCommand{
 				Name:         "cwdonator",
-				Description:  "Teilt automatisch zufällige Spender für den aktuellen Clan War ein.",
+				Description:  "Teilt Spender für den aktuellen Clan War ein, selten eingeteilte Mitglieder werden bevorzugt.",
 				Type:         discordgo.ChatApplicationCommand,
 				DMPermission: util.BoolPtr(false),
 				Options: []*discordgo.ApplicationCommandOption{
@@ -57,6 +66,34 @@ func clanInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.Com
 				},
 			},
 		},
+		{
+			Handler: types.InteractionHandler{
+				Main:         handler.CWDonorPreference,
+				Autocomplete: handler.HandleAutocomplete,
+			},
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:         "cwdonorpreference",
+				Description:  "Legt fest, ob du von /cwdonator als Spender eingeteilt werden kannst.",
+				Type:         discordgo.ChatApplicationCommand,
+				DMPermission: util.BoolPtr(false),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        handlers.EnabledOptionName,
+						Description: "Ob du als Spender eingeteilt werden möchtest.",
+						Required:    true,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         handlers.MyPlayerTagOptionName,
+						Description:  "Account, für den die Einstellung gilt. Ohne Angabe gilt sie für alle deine Accounts.",
+						MinLength:    util.IntPtr(validation.TagMinLength),
+						MaxLength:    validation.TagMaxLength,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
 		{
 			Handler: types.InteractionHandler{
 				Main:         handler.RaidPing,
@@ -71,6 +108,114 @@ func clanInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.Com
 					optionClanTag("Clan, dessen Mitglieder einen Ping erhalten sollen."),
 				},
 			},
+		}, {
+			Handler: types.InteractionHandler{
+				Main:         handler.RaidReminders,
+				Autocomplete: handler.HandleAutocomplete,
+			},
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:         "raidreminders",
+				Description:  "Legt fest, wann Mitglieder mit offenen Raid Angriffen im Raid-Channel erinnert werden.",
+				Type:         discordgo.ChatApplicationCommand,
+				DMPermission: util.BoolPtr(false),
+				Options: []*discordgo.ApplicationCommandOption{
+					optionClanTag("Clan, dessen Erinnerungen festgelegt werden sollen."),
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        handlers.TimesOptionName,
+						Description: "Tage und Uhrzeiten in deutscher Zeit, kommagetrennt (z.B. So 18:00,Mo 06:00). 0 deaktiviert.",
+						Required:    true,
+						MinLength:   util.IntPtr(1),
+						MaxLength:   100,
+					},
+				},
+			},
+		}, {
+			Handler: types.InteractionHandler{
+				Main:         handler.RaidStats,
+				Autocomplete: handler.HandleAutocomplete,
+			},
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:         "raidstats",
+				Description:  "Zeigt erbeutetes Clan Gold, offene Angriffe und die Ergebnisse der letzten Raid Wochenenden.",
+				Type:         discordgo.ChatApplicationCommand,
+				DMPermission: util.BoolPtr(false),
+				Options: []*discordgo.ApplicationCommandOption{
+					optionClanTag("Clan, dessen Raid Statistiken angezeigt werden sollen."),
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        handlers.WeekendsOptionName,
+						Description: "Anzahl der letzten Raid Wochenenden, die berücksichtigt werden sollen (Standard: 4).",
+						MinValue:    util.FloatPtr(1),
+						MaxValue:    25,
+					},
+				},
+			},
+		}, {
+			Handler: types.InteractionHandler{
+				Main:         handler.ClanGamesSettings,
+				Autocomplete: handler.HandleAutocomplete,
+			},
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:         "clangamessettings",
+				Description:  "Legt fest, wie viele Punkte Mitglieder in den Clan Spielen mindestens erreichen müssen.",
+				Type:         discordgo.ChatApplicationCommand,
+				DMPermission: util.BoolPtr(false),
+				Options: []*discordgo.ApplicationCommandOption{
+					optionClanTag("Clan, dessen Einstellungen festgelegt werden sollen."),
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        handlers.MinPointsOptionName,
+						Description: "Mindestanzahl an Punkten, 0 deaktiviert die Meldung im Leader-Channel.",
+						Required:    true,
+						MinValue:    util.FloatPtr(0),
+						MaxValue:    validation.MaxClanGamesMinPoints,
+					},
+				},
+			},
+		}, {
+			Handler: types.InteractionHandler{
+				Main:         handler.Donations,
+				Autocomplete: handler.HandleAutocomplete,
+			},
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:         "donations",
+				Description:  "Spenden-Rangliste einer Season mit dem Verhältnis aus gespendeten und erhaltenen Truppen.",
+				Type:         discordgo.ChatApplicationCommand,
+				DMPermission: util.BoolPtr(false),
+				Options: []*discordgo.ApplicationCommandOption{
+					optionClanTag("Clan, dessen Spenden angezeigt werden sollen."),
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        handlers.SeasonOptionName,
+						Description: "Season im Format YYYY-MM (Standard: aktuelle Season).",
+						MinLength:   util.IntPtr(7),
+						MaxLength:   7,
+					},
+				},
+			},
+		}, {
+			Handler: types.InteractionHandler{
+				Main:         handler.DonationSettings,
+				Autocomplete: handler.HandleAutocomplete,
+			},
+			ApplicationCommand: &discordgo.ApplicationCommand{
+				Name:         "donationsettings",
+				Description:  "Legt das minimale Spendenverhältnis fest, unter dem Mitglieder bei /donations aufgeführt werden.",
+				Type:         discordgo.ChatApplicationCommand,
+				DMPermission: util.BoolPtr(false),
+				Options: []*discordgo.ApplicationCommandOption{
+					optionClanTag("Clan, dessen Einstellungen festgelegt werden sollen."),
+					{
+						Type:        discordgo.ApplicationCommandOptionNumber,
+						Name:        handlers.MinRatioOptionName,
+						Description: "Minimales Verhältnis aus gespendeten und erhaltenen Truppen, 0 deaktiviert die Meldung.",
+						Required:    true,
+						MinValue:    util.FloatPtr(0),
+						MaxValue:    validation.MaxMinDonationRatio,
+					},
+				},
+			},
 		}, {
 			Handler: types.InteractionHandler{
 				Main: handler.EventInfo,
@@ -30,6 +30,9 @@ func Setup(s *discordgo.Session) ([]*discordgo.ApplicationCommand, error) {
 	}
 
 	s.AddHandler(interactionHandler(interactions))
+	for _, handler := range guildMemberEventHandlers(db) {
+		s.AddHandler(handler)
+	}
 	return cmds, nil
 }
 
@@ -0,0 +1,28 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ApplicationQuestions are asked in the application modal, in the same order as the text inputs.
+var ApplicationQuestions = []string{
+	"Warum möchtest du unserem Clan beitreten?",
+	"Wie aktiv bist du in Clankriegen und CWL?",
+	"Was sollten wir noch über dich wissen?",
+}
+
+func ApplicationModalComponents() []discordgo.MessageComponent {
+	inputs := make([]discordgo.MessageComponent, len(ApplicationQuestions))
+	for i, question := range ApplicationQuestions {
+		inputs[i] = &discordgo.TextInput{
+			CustomID:  fmt.Sprintf("application_question_%d", i),
+			Label:     question,
+			Style:     discordgo.TextInputParagraph,
+			Required:  i < len(ApplicationQuestions)-1,
+			MaxLength: 500,
+		}
+	}
+	return GenModalComponents(inputs...)
+}
@@ -0,0 +1,49 @@
+package components
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	ConfirmAction = "confirm"
+	CancelAction  = "cancel"
+
+	maxButtonsPerRow = 5
+)
+
+// ButtonRow returns the buttons in a single action row.
+func ButtonRow(buttons ...discordgo.Button) []discordgo.MessageComponent {
+	c := make([]discordgo.MessageComponent, len(buttons))
+	for i, button := range buttons {
+		c[i] = button
+	}
+
+	return []discordgo.MessageComponent{
+		&discordgo.ActionsRow{Components: c},
+	}
+}
+
+// ButtonRows returns the buttons in action rows of up to five buttons.
+func ButtonRows(buttons ...discordgo.Button) []discordgo.MessageComponent {
+	var rows []discordgo.MessageComponent
+	for start := 0; start < len(buttons); start += maxButtonsPerRow {
+		rows = append(rows, ButtonRow(buttons[start:min(start+maxButtonsPerRow, len(buttons))]...)...)
+	}
+	return rows
+}
+
+// ConfirmButtons returns an action row with a confirm and a cancel button.
+func ConfirmButtons(confirmCustomID, cancelCustomID string) []discordgo.MessageComponent {
+	return ButtonRow(
+		discordgo.Button{
+			Label:    "Bestätigen",
+			Style:    discordgo.DangerButton,
+			CustomID: confirmCustomID,
+		},
+		discordgo.Button{
+			Label:    "Abbrechen",
+			Style:    discordgo.SecondaryButton,
+			CustomID: cancelCustomID,
+		},
+	)
+}
@@ -0,0 +1,22 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/types"
+)
+
+// CWDonorRerollButtons returns a reroll button for every donor range, in rows of five buttons.
+func CWDonorRerollButtons(ranges []types.CWDonorRange, customID func(donorRange types.CWDonorRange) string) []discordgo.MessageComponent {
+	buttons := make([]discordgo.Button, len(ranges))
+	for index, donorRange := range ranges {
+		buttons[index] = discordgo.Button{
+			Label:    fmt.Sprintf("%d-%d neu auslosen", donorRange.Start, donorRange.End),
+			Style:    discordgo.SecondaryButton,
+			CustomID: customID(donorRange),
+		}
+	}
+	return ButtonRows(buttons...)
+}
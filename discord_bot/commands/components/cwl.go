@@ -0,0 +1,63 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/store/postgres/models"
+)
+
+const (
+	// MaxCWLSignupButtons is the maximum number of accounts that can be shown. A message holds at most 5 rows,
+	// the buttons use 4 of them and the last one holds the clan preference.
+	MaxCWLSignupButtons = 20
+
+	// NoCWLPreference is the value of the select option for signups without a preferred clan.
+	NoCWLPreference = "none"
+
+	maxSelectOptions = 25
+)
+
+// CWLSignupButtons returns a toggle button for every account. Signed up accounts have a green button.
+func CWLSignupButtons(players models.Players, signedUp map[string]bool, customID func(player *models.Player) string) []discordgo.MessageComponent {
+	buttons := make([]discordgo.Button, 0, min(len(players), MaxCWLSignupButtons))
+	for _, player := range players[:min(len(players), MaxCWLSignupButtons)] {
+		button := discordgo.Button{
+			Label:    fmt.Sprintf("%s (%s)", player.Name, player.CocTag),
+			Style:    discordgo.SecondaryButton,
+			CustomID: customID(player),
+		}
+		if signedUp[player.CocTag] {
+			button.Style = discordgo.SuccessButton
+		}
+		buttons = append(buttons, button)
+	}
+	return ButtonRows(buttons...)
+}
+
+// CWLPreferenceSelect returns a select menu in its own row to choose the preferred clan of all signed up accounts.
+func CWLPreferenceSelect(clans models.Clans, preferredClanTag string, customID string) []discordgo.MessageComponent {
+	options := make([]discordgo.SelectMenuOption, 0, min(len(clans)+1, maxSelectOptions))
+	options = append(options, discordgo.SelectMenuOption{
+		Label:   "Keine Präferenz",
+		Value:   NoCWLPreference,
+		Default: preferredClanTag == "",
+	})
+	for _, clan := range clans[:min(len(clans), maxSelectOptions-1)] {
+		options = append(options, discordgo.SelectMenuOption{
+			Label:   clan.Name,
+			Value:   clan.Tag,
+			Default: clan.Tag == preferredClanTag,
+		})
+	}
+
+	return []discordgo.MessageComponent{discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{discordgo.SelectMenu{
+			MenuType:    discordgo.StringSelectMenu,
+			CustomID:    customID,
+			Placeholder: "Bevorzugter Clan",
+			Options:     options,
+		}},
+	}}
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/handlers"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/types"
+)
+
+func cwlInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.Commands[types.InteractionHandler] {
+	handler := handlers.NewCWLHandler(
+		repos.NewClansRepo(db),
+		repos.NewPlayersRepo(db),
+		repos.NewMembersRepo(db),
+		repos.NewCWLRepo(db),
+		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
+		clashClient,
+	)
+
+	return types.Commands[types.InteractionHandler]{{
+		Handler: types.InteractionHandler{
+			Main:      handler.CWLSignup,
+			Component: handler.CWLSignupComponent,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "cwlsignup",
+			Description:  "Sendet eine Nachricht, mit der sich Mitglieder für die CWL anmelden können.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionCWLSeason(),
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main: handler.CWLPlan,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "cwlplan",
+			Description:  "Verteilt die angemeldeten Accounts auf die Clans und exportiert die Aufstellungen.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionCWLSeason(),
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.RosterSizeOptionName,
+					Description: "Anzahl der Accounts pro Clan (Standard: 15).",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "15", Value: 15},
+						{Name: "30", Value: 30},
+					},
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.CWLGroup,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "cwlgroup",
+			Description:  "Zeigt alle Clans der aktuellen CWL Gruppe mit ihren Rathaus-Leveln an.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen CWL Gruppe angezeigt werden soll."),
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.CWLBonus,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "cwlbonus",
+			Description:  "Bewertet die Angriffe der aktuellen CWL und schlägt vor, wer die Bonusmedaillen bekommt.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen CWL bewertet werden soll."),
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.BonusesOptionName,
+					Description: "Anzahl der verfügbaren Boni.",
+					Required:    true,
+					MinValue:    util.FloatPtr(1),
+					MaxValue:    30,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.StarsOptionName,
+					Description: "Punkte pro Stern (Standard: 10).",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    100,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.DestructionOptionName,
+					Description: "Punkte pro 100% Zerstörung (Standard: 10).",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    100,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.AttacksOptionName,
+					Description: "Punkte pro genutztem Angriff (Standard: 5).",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    100,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.TownHallDiffOptionName,
+					Description: "Punkte pro Rathaus-Level, das der Gegner über dem eigenen liegt (Standard: 5).",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    100,
+				},
+			},
+		},
+	}}
+}
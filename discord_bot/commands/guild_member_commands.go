@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/handlers"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/types"
+)
+
+func newGuildMemberHandler(db *gorm.DB) handlers.IGuildMemberHandler {
+	return handlers.NewGuildMemberHandler(
+		repos.NewPlayersRepo(db),
+		repos.NewMembersRepo(db),
+		repos.NewClansRepo(db),
+		repos.NewGuildsRepo(db),
+		repos.NewClanSettingsRepo(db),
+		repos.NewKickpointsRepo(db),
+		repos.NewBlacklistRepo(db),
+		repos.NewNicknamesRepo(db),
+		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
+	)
+}
+
+func guildMemberInteractionCommands(db *gorm.DB) types.Commands[types.InteractionHandler] {
+	handler := newGuildMemberHandler(db)
+
+	return types.Commands[types.InteractionHandler]{{
+		Handler: types.InteractionHandler{
+			Main:         handler.LeftMembers,
+			Autocomplete: handler.HandleAutocomplete,
+			Component:    handler.LeftMembersComponent,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "leftmembers",
+			Description:  "Zeigt alle Mitglieder eines Clans an, die den Discord Server verlassen haben.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Mitglieder angezeigt werden sollen."),
+			},
+		},
+	}}
+}
+
+// guildMemberEventHandlers returns the handlers for members joining and leaving the Discord server.
+func guildMemberEventHandlers(db *gorm.DB) []any {
+	handler := newGuildMemberHandler(db)
+	return []any{handler.OnGuildMemberRemove, handler.OnGuildMemberAdd}
+}
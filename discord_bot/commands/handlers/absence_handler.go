@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/messages"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/commands/validation"
+	"bot/store/postgres/models"
+)
+
+const (
+	AbsenceAddSubcommand    = "add"
+	AbsenceListSubcommand   = "list"
+	AbsenceRemoveSubcommand = "remove"
+)
+
+type IAbsenceHandler interface {
+	Absence(s *discordgo.Session, i *discordgo.InteractionCreate)
+	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
+}
+
+type AbsenceHandler struct {
+	absences repos.IAbsencesRepo
+	players  repos.IPlayersRepo
+}
+
+func NewAbsenceHandler(absences repos.IAbsencesRepo, players repos.IPlayersRepo) IAbsenceHandler {
+	return &AbsenceHandler{
+		absences: absences,
+		players:  players,
+	}
+}
+
+func (h *AbsenceHandler) Absence(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	if len(opts) != 1 {
+		messages.SendInvalidInputErr(i, "Bitte wähle eine Aktion aus.")
+		return
+	}
+
+	switch opts[0].Name {
+	case AbsenceAddSubcommand:
+		h.addAbsence(i, opts[0].Options)
+	case AbsenceListSubcommand:
+		h.listAbsences(i)
+	case AbsenceRemoveSubcommand:
+		h.removeAbsence(i, opts[0].Options)
+	default:
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+	}
+}
+
+func (h *AbsenceHandler) addAbsence(i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	reason := util.StringOptionByName(ReasonOptionName, opts)
+	if reason == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Grund an.")
+		return
+	}
+
+	firstDay := util.TruncateToDay(time.Now())
+	if startsAt := util.StringOptionByName(StartsAtOptionName, opts); startsAt != "" {
+		date, err := util.ParseDateString(startsAt)
+		if err != nil {
+			messages.SendInvalidInputErr(i, fmt.Sprintf("Das Datumsformat vom Feld %s ist ungültig. Bitte gib ein Datum im Format `DD.MM.YYYY` an.", StartsAtOptionName))
+			return
+		}
+		firstDay = date
+	}
+
+	lastDay, err := util.ParseDateString(util.StringOptionByName(EndsAtOptionName, opts))
+	if err != nil {
+		messages.SendInvalidInputErr(i, fmt.Sprintf("Das Datumsformat vom Feld %s ist ungültig. Bitte gib ein Datum im Format `DD.MM.YYYY` an.", EndsAtOptionName))
+		return
+	}
+	if msg, ok := validation.ValidateAbsenceDates(firstDay, lastDay); !ok {
+		messages.SendInvalidInputErr(i, msg)
+		return
+	}
+
+	var players models.Players
+	if playerTag := util.StringOptionByName(MyPlayerTagOptionName, opts); playerTag != "" {
+		if !strings.HasPrefix(playerTag, "#") {
+			playerTag = "#" + playerTag
+		}
+		player, err := h.players.PlayerByTagAndDiscordID(strings.ToUpper(playerTag), i.Member.User.ID)
+		if err != nil {
+			messages.SendErr(i, fmt.Sprintf("Der Account %s ist nicht mit deinem Discord Account verknüpft.", playerTag))
+			return
+		}
+		players = models.Players{player}
+	} else {
+		if players, err = h.players.PlayersByDiscordID(i.Member.User.ID); err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+		if len(players) == 0 {
+			messages.SendErr(i, "Mit deinem Discord Account sind keine Accounts verknüpft.")
+			return
+		}
+	}
+
+	absences := make([]*models.Absence, len(players))
+	for index, player := range players {
+		absences[index] = &models.Absence{
+			PlayerTag:          player.CocTag,
+			StartsAt:           firstDay,
+			EndsAt:             lastDay.AddDate(0, 0, 1),
+			Reason:             reason,
+			CreatedByDiscordID: i.Member.User.ID,
+			CreatedAt:          time.Now(),
+		}
+	}
+	if err = h.absences.CreateAbsences(absences); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	names := make([]string, len(players))
+	for index, player := range players {
+		names[index] = player.Name
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Abwesenheit eingetragen",
+		fmt.Sprintf("Abwesenheit vom %s für %s eingetragen. In dieser Zeit wirst du bei Raid Pings und Kriegserinnerungen übersprungen.", messages.FormatAbsencePeriod(absences[0]), strings.Join(names, ", ")),
+		messages.ColorGreen,
+	))
+}
+
+func (h *AbsenceHandler) listAbsences(i *discordgo.InteractionCreate) {
+	absences, err := h.absences.AbsencesByDiscordID(i.Member.User.ID)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.AbsencesEmbed(absences))
+}
+
+func (h *AbsenceHandler) removeAbsence(i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	id := util.UintOptionByName(IDOptionName, opts)
+	if id == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib die ID der Abwesenheit an.")
+		return
+	}
+
+	if err := h.absences.DeleteAbsence(*id, i.Member.User.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			messages.SendErr(i, fmt.Sprintf("Du hast keine Abwesenheit mit der ID %d. Nutze `/absence list`, um deine Abwesenheiten anzuzeigen.", *id))
+			return
+		}
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Abwesenheit entfernt",
+		fmt.Sprintf("Die Abwesenheit mit der ID %d wurde entfernt.", *id),
+		messages.ColorGreen,
+	))
+}
+
+func (h *AbsenceHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	for _, subcommand := range i.ApplicationCommandData().Options {
+		for _, opt := range subcommand.Options {
+			if opt.Focused && opt.Name == MyPlayerTagOptionName {
+				autocompleteMyPlayers(i, h.players, opt.StringValue())
+			}
+		}
+	}
+}
+
+// activeAbsences returns the running absences of the players by player tag. Errors are logged and result in no absences.
+func activeAbsences(repo repos.IAbsencesRepo, playerTags []string) map[string]*models.Absence {
+	absences, err := repo.ActiveAbsences(playerTags)
+	if err != nil {
+		slog.Error("Error while getting active absences.", slog.Any("err", err))
+		return nil
+	}
+
+	absenceByTag := make(map[string]*models.Absence, len(absences))
+	for _, absence := range absences {
+		// absences are ordered by ends_at descending, so the latest return date is kept
+		if _, ok := absenceByTag[absence.PlayerTag]; !ok {
+			absenceByTag[absence.PlayerTag] = absence
+		}
+	}
+	return absenceByTag
+}
+
+// withoutAbsentMembers returns the members which are currently not absent. Used by pings and reminders to skip absent members.
+func withoutAbsentMembers(repo repos.IAbsencesRepo, members models.ClanMembers) models.ClanMembers {
+	absenceByTag := activeAbsences(repo, members.Tags())
+	return slices.DeleteFunc(slices.Clone(members), func(member *models.ClanMember) bool {
+		_, ok := absenceByTag[member.PlayerTag]
+		return ok
+	})
+}
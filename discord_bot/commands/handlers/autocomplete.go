@@ -44,3 +44,13 @@ func autocompletePlayers(i *discordgo.InteractionCreate, repo repos.IPlayersRepo
 
 	messages.SendAutoCompletion(i, players.Choices())
 }
+
+func autocompleteMyPlayers(i *discordgo.InteractionCreate, repo repos.IPlayersRepo, query string) {
+	players, err := repo.MyPlayers(i.Member.User.ID, query)
+	if err != nil {
+		messages.SendAutoCompletion(i, nil)
+		return
+	}
+
+	messages.SendAutoCompletion(i, players.Choices())
+}
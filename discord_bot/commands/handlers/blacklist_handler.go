@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/messages"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+const (
+	BlacklistAddSubcommand    = "add"
+	BlacklistListSubcommand   = "list"
+	BlacklistRemoveSubcommand = "remove"
+)
+
+type IBlacklistHandler interface {
+	Blacklist(s *discordgo.Session, i *discordgo.InteractionCreate)
+	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
+}
+
+type BlacklistHandler struct {
+	blacklist repos.IBlacklistRepo
+	players   repos.IPlayersRepo
+	members   repos.IMembersRepo
+	auth      middleware.AuthMiddleware
+}
+
+func NewBlacklistHandler(blacklist repos.IBlacklistRepo, players repos.IPlayersRepo, members repos.IMembersRepo, auth middleware.AuthMiddleware) IBlacklistHandler {
+	return &BlacklistHandler{
+		blacklist: blacklist,
+		players:   players,
+		members:   members,
+		auth:      auth,
+	}
+}
+
+func (h *BlacklistHandler) Blacklist(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := h.auth.AuthorizeAdminInteraction(i); err != nil {
+		return
+	}
+
+	opts := i.ApplicationCommandData().Options
+	if len(opts) != 1 {
+		messages.SendInvalidInputErr(i, "Bitte wähle eine Aktion aus.")
+		return
+	}
+
+	switch opts[0].Name {
+	case BlacklistAddSubcommand:
+		h.addEntry(i, opts[0].Options)
+	case BlacklistListSubcommand:
+		h.listEntries(i)
+	case BlacklistRemoveSubcommand:
+		h.removeEntry(i, opts[0].Options)
+	default:
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+	}
+}
+
+func (h *BlacklistHandler) addEntry(i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	playerTag := util.StringOptionByName(PlayerTagOptionName, opts)
+	reason := util.StringOptionByName(ReasonOptionName, opts)
+	if playerTag == "" || reason == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Spieler und einen Grund an.")
+		return
+	}
+	if !strings.HasPrefix(playerTag, "#") {
+		playerTag = "#" + playerTag
+	}
+
+	entry := &models.BlacklistEntry{
+		PlayerTag:          strings.ToUpper(playerTag),
+		Reason:             reason,
+		CreatedByDiscordID: i.Member.User.ID,
+		CreatedAt:          time.Now(),
+	}
+
+	if expiresAt := util.StringOptionByName(ExpiresAtOptionName, opts); expiresAt != "" {
+		date, err := util.ParseDateString(expiresAt)
+		if err != nil {
+			messages.SendInvalidInputErr(i, fmt.Sprintf("Das Datumsformat vom Feld %s ist ungültig. Bitte gib ein Datum im Format `DD.MM.YYYY` an.", ExpiresAtOptionName))
+			return
+		}
+		if !date.After(time.Now()) {
+			messages.SendInvalidInputErr(i, "Das Ablaufdatum muss in der Zukunft liegen.")
+			return
+		}
+		entry.ExpiresAt = &date
+	}
+
+	if err := h.blacklist.SaveBlacklistEntry(entry); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	if saved, err := h.blacklist.ActiveBlacklistEntry(entry.PlayerTag); err == nil {
+		entry = saved
+	}
+
+	desc := messages.BlacklistWarning(entry)
+	if members, err := h.members.MembersByPlayerTag(entry.PlayerTag); err == nil {
+		for _, member := range members {
+			desc += fmt.Sprintf("\n\n**ACHTUNG**: Der Spieler ist aktuell Mitglied von %s und wurde nicht automatisch entfernt.", memberClanName(member))
+		}
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Spieler zur Blacklist hinzugefügt",
+		desc,
+		messages.ColorGreen,
+	))
+}
+
+func (h *BlacklistHandler) listEntries(i *discordgo.InteractionCreate) {
+	entries, err := h.blacklist.ActiveBlacklistEntries()
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.BlacklistEmbed(entries))
+}
+
+func (h *BlacklistHandler) removeEntry(i *discordgo.InteractionCreate, opts []*discordgo.ApplicationCommandInteractionDataOption) {
+	playerTag := util.StringOptionByName(PlayerTagOptionName, opts)
+	if playerTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Spieler an.")
+		return
+	}
+	if !strings.HasPrefix(playerTag, "#") {
+		playerTag = "#" + playerTag
+	}
+	playerTag = strings.ToUpper(playerTag)
+
+	if err := h.blacklist.DeleteBlacklistEntry(playerTag); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			messages.SendErr(i, fmt.Sprintf("%s steht nicht auf der Blacklist.", playerTag))
+			return
+		}
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Spieler von der Blacklist entfernt",
+		fmt.Sprintf("%s wurde von der Blacklist entfernt.", playerTag),
+		messages.ColorGreen,
+	))
+}
+
+func (h *BlacklistHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	for _, subcommand := range i.ApplicationCommandData().Options {
+		for _, opt := range subcommand.Options {
+			if opt.Focused && opt.Name == PlayerTagOptionName {
+				autocompletePlayers(i, h.players, opt.StringValue())
+			}
+		}
+	}
+}
+
+// blacklistEntry returns the active blacklist entry of the player, or nil if the player is not blacklisted.
+func blacklistEntry(repo repos.IBlacklistRepo, playerTag string) *models.BlacklistEntry {
+	entry, err := repo.ActiveBlacklistEntry(playerTag)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Error("Error while checking blacklist.", slog.Any("err", err), slog.String("playerTag", playerTag))
+		}
+		return nil
+	}
+	return entry
+}
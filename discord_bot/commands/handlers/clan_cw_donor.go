@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/components"
+	"bot/commands/messages"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	cwDonatorCommandName = "cwdonator" // component ids of the reroll buttons are routed to this command
+
+	rerollDonorAction = "reroll"
+)
+
+func (h *ClanHandler) CWDonatorComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, _, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	if action != rerollDonorAction || len(values) != 3 {
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+		return
+	}
+
+	clanTag, preparationStartTime := values[0], values[1]
+	rangeStart, err := strconv.Atoi(values[2])
+	if err != nil {
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+		return
+	}
+
+	if err = h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	if err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}); err != nil {
+		slog.Error("Failed to send deferred response", slog.Any("err", err))
+		return
+	}
+
+	clanWar, err := h.clashClient.GetCurrentClanWar(clanTag)
+	if err != nil {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Fehler", "Beim Abrufen der aktuellen Clan Kriegsdaten ist ein Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+	if clanWar.PreparationStartTime != preparationStartTime {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Krieg beendet", "Die Spender gehören zu einem Krieg, der bereits vorbei ist.", messages.ColorRed))
+		return
+	}
+
+	prepStart, err := util.ParseClashDate(preparationStartTime)
+	if err != nil {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+
+	assignments, err := h.cwDonors.WarDonorAssignments(clanTag, prepStart)
+	if err != nil {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+
+	var assignment *models.CWDonorAssignment
+	assigned := make(map[string]bool, len(assignments))
+	for _, a := range assignments {
+		assigned[a.PlayerTag] = true
+		if a.RangeStart == rangeStart {
+			assignment = a
+		}
+	}
+	if assignment == nil {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Nicht gefunden", "Für diesen Bereich wurde kein Spender gespeichert.", messages.ColorRed))
+		return
+	}
+
+	members, err := h.cwDonatorMembers(clanTag, clanWar.Clan.Members)
+	if err != nil {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+
+	candidates, err := cwDonorCandidates(h.clashClient, h.cwDonors, h.absences, clanTag, clanWar.Clan.Members, members)
+	if err != nil {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Fehler", "Beim Abrufen der Kriegs Teilnehmer ist ein Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+
+	// the current donors of all ranges are only selected again if nobody else is left
+	donorRange := types.CWDonorRange{Start: assignment.RangeStart, End: assignment.RangeEnd}
+	donor := util.SelectCWDonor(donorRange, candidates, assigned)
+	if donor == nil || donor.Tag == assignment.PlayerTag {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Kein anderer Spender", fmt.Sprintf("Für %d-%d gibt es keinen anderen Spender.", donorRange.Start, donorRange.End), messages.ColorRed))
+		return
+	}
+
+	assignment.PlayerTag = donor.Tag
+	assignment.AssignedAt = time.Now()
+	if err = h.cwDonors.SaveDonorAssignment(assignment); err != nil {
+		messages.SendEphemeralFollowup(i, messages.NewEmbed("Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+
+	content := messages.ReplaceCWDonatorLine(i.Message.Content, donor)
+	if _, err = util.Session.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+		slog.Error("Failed to edit message.", slog.Any("err", err))
+	}
+
+	// edited messages don't ping, so the new donor is mentioned again
+	if _, err = util.Session.ChannelMessageSend(i.ChannelID, fmt.Sprintf("Neu ausgelost von %s: %s", i.Member.Mention(), messages.CWDonatorLine(donor))); err != nil {
+		slog.Error("Error sending message", slog.Any("err", err))
+	}
+}
+
+func (h *ClanHandler) CWDonorPreference(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	enabled := util.BoolOptionByName(EnabledOptionName, opts)
+	if enabled == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib an, ob du als Spender eingeteilt werden möchtest.")
+		return
+	}
+
+	var players models.Players
+	if playerTag := util.StringOptionByName(MyPlayerTagOptionName, opts); playerTag != "" {
+		if !strings.HasPrefix(playerTag, "#") {
+			playerTag = "#" + playerTag
+		}
+		player, err := h.players.PlayerByTagAndDiscordID(strings.ToUpper(playerTag), i.Member.User.ID)
+		if err != nil {
+			messages.SendErr(i, fmt.Sprintf("Der Account %s ist nicht mit deinem Discord Account verknüpft.", playerTag))
+			return
+		}
+		players = models.Players{player}
+	} else {
+		var err error
+		if players, err = h.players.PlayersByDiscordID(i.Member.User.ID); err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+		if len(players) == 0 {
+			messages.SendErr(i, "Mit deinem Discord Account sind keine Accounts verknüpft.")
+			return
+		}
+	}
+
+	names := make([]string, len(players))
+	for index, player := range players {
+		if err := h.cwDonors.SetDonorOptOut(player.CocTag, !*enabled); err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+		names[index] = player.Name
+	}
+
+	desc := fmt.Sprintf("%s wird nicht mehr als CW Spender eingeteilt.", strings.Join(names, ", "))
+	if *enabled {
+		desc = fmt.Sprintf("%s kann wieder als CW Spender eingeteilt werden.", strings.Join(names, ", "))
+	}
+	messages.SendEmbedResponse(i, messages.NewEmbed("Einstellung gespeichert", desc, messages.ColorGreen))
+}
+
+// cwDonorRangeSize returns the range size configured for the clan. Clans outside the family have no settings and use the default.
+func (h *ClanHandler) cwDonorRangeSize(clanTag string) int {
+	if _, err := h.clans.ClanByTag(clanTag); err != nil {
+		return util.DefaultCWDonorRangeSize
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		slog.Error("Error while getting clan settings.", slog.Any("err", err), slog.String("clanTag", clanTag))
+		return util.DefaultCWDonorRangeSize
+	}
+	return settings.CWDonorRangeSize
+}
+
+// cwDonatorMembers returns the members used to mention the donors. For clans outside the family, the members are looked up by the war participants.
+func (h *ClanHandler) cwDonatorMembers(clanTag string, warMembers []goclash.ClanWarMember) (models.ClanMembers, error) {
+	if _, err := h.clans.ClanByTag(clanTag); err == nil {
+		return h.members.MembersByClanTag(clanTag)
+	}
+
+	var members models.ClanMembers
+	for _, warMember := range warMembers {
+		member, _ := h.members.MembersByPlayerTag(warMember.Tag)
+		if member != nil {
+			members = append(members, member...)
+		}
+	}
+	return members, nil
+}
+
+// cwDonorCandidates returns the war members who want to donate and are not absent, together with their recent assignments in the clan.
+func cwDonorCandidates(clashClient *goclash.Client, cwDonors repos.ICWDonorsRepo, absences repos.IAbsencesRepo, clanTag string, warMembers []goclash.ClanWarMember, members models.ClanMembers) ([]*types.CWDonorCandidate, error) {
+	tags := make([]string, len(warMembers))
+	for index, warMember := range warMembers {
+		tags[index] = warMember.Tag
+	}
+
+	players, err := clashClient.GetPlayersWithError(tags...)
+	if err != nil {
+		return nil, err
+	}
+	playerByTag := make(map[string]*goclash.Player, len(players))
+	for _, player := range players {
+		playerByTag[player.Tag] = player
+	}
+
+	optedOutTags, err := cwDonors.OptedOutPlayerTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	optedOut := make(map[string]bool, len(optedOutTags))
+	for _, tag := range optedOutTags {
+		optedOut[tag] = true
+	}
+
+	assignments, err := cwDonors.DonorAssignmentsSince(clanTag, time.Now().AddDate(0, 0, -util.CWDonorLookbackDays))
+	if err != nil {
+		return nil, err
+	}
+
+	discordIDByTag := make(map[string]string, len(members))
+	for _, member := range members {
+		if member.Player != nil {
+			discordIDByTag[member.PlayerTag] = member.Player.DiscordID
+		}
+	}
+	absenceByTag := activeAbsences(absences, tags)
+
+	candidateByTag := make(map[string]*types.CWDonorCandidate, len(warMembers))
+	candidates := make([]*types.CWDonorCandidate, 0, len(warMembers))
+	for _, warMember := range warMembers {
+		player, ok := playerByTag[warMember.Tag]
+		if !ok {
+			slog.Error("clanMember not found", slog.String("tag", warMember.Tag))
+			continue
+		}
+		if player.WarPreference != "in" || optedOut[warMember.Tag] {
+			continue
+		}
+		if _, absent := absenceByTag[warMember.Tag]; absent {
+			continue
+		}
+
+		candidate := &types.CWDonorCandidate{
+			Tag:         warMember.Tag,
+			Name:        warMember.Name,
+			DiscordID:   discordIDByTag[warMember.Tag],
+			MapPosition: warMember.MapPosition,
+		}
+		candidateByTag[candidate.Tag] = candidate
+		candidates = append(candidates, candidate)
+	}
+
+	// assignments are ordered by assigned_at descending, so the first one is the latest
+	for _, assignment := range assignments {
+		candidate, ok := candidateByTag[assignment.PlayerTag]
+		if !ok {
+			continue
+		}
+		if candidate.LastAssignedAt == nil {
+			assignedAt := assignment.AssignedAt
+			candidate.LastAssignedAt = &assignedAt
+		}
+		candidate.RecentAssignments++
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].MapPosition < candidates[j].MapPosition
+	})
+	return candidates, nil
+}
+
+// saveCWDonors replaces the saved donors of the war, so running /cwdonator again doesn't count twice.
+func saveCWDonors(repo repos.ICWDonorsRepo, clanTag, preparationStartTime string, donors []*types.CWDonor) error {
+	prepStart, err := util.ParseClashDate(preparationStartTime)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	assignments := make([]*models.CWDonorAssignment, len(donors))
+	for index, donor := range donors {
+		assignments[index] = &models.CWDonorAssignment{
+			ClanTag:              clanTag,
+			PreparationStartTime: prepStart,
+			RangeStart:           donor.Range.Start,
+			RangeEnd:             donor.Range.End,
+			PlayerTag:            donor.Tag,
+			AssignedAt:           now,
+		}
+	}
+	return repo.ReplaceWarDonorAssignments(clanTag, prepStart, assignments)
+}
+
+func cwDonorRerollButtons(clanTag, preparationStartTime string, donors []*types.CWDonor) []discordgo.MessageComponent {
+	ranges := make([]types.CWDonorRange, len(donors))
+	for index, donor := range donors {
+		ranges[index] = donor.Range
+	}
+
+	return components.CWDonorRerollButtons(ranges, func(donorRange types.CWDonorRange) string {
+		return util.BuildComponentID(cwDonatorCommandName, "", rerollDonorAction, clanTag, preparationStartTime, strconv.Itoa(donorRange.Start))
+	})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	donationTrackInterval = time.Hour
+
+	// donationFinalSnapshotLead is how long before the season reset the last snapshot of the season is taken,
+	// so that the donations of the last hour of the season are not lost.
+	donationFinalSnapshotLead = time.Minute * 5
+)
+
+func (h *ClanHandler) Donations(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	season := util.StringOptionByName(SeasonOptionName, opts)
+	if season == "" {
+		season = util.DonationSeason(time.Now())
+	} else if !util.ValidDonationSeason(season) {
+		messages.SendInvalidInputErr(i, "Die Season muss im Format `YYYY-MM` angegeben werden, z.B. `2024-03`.")
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	snapshots, err := h.donations.DonationSnapshots(clanTag, season)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.DonationsEmbed(clanName, season, snapshots, settings.MinDonationRatio))
+}
+
+func (h *ClanHandler) DonationSettings(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	minRatio := util.FloatOptionByName(MinRatioOptionName, opts)
+	if clanTag == "" || minRatio == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und das minimale Verhältnis an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	settings.MinDonationRatio = *minRatio
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.DonationSettingsEmbed(clanName, settings))
+}
+
+// trackDonations periodically saves the season donations of every member, and once more shortly before the season reset.
+// The snapshot of the current season is overwritten each time, so that the last values before the reset remain once the next season has begun.
+func (h *ClanHandler) trackDonations() {
+	ticker := time.NewTicker(donationTrackInterval)
+	final := time.NewTimer(untilFinalDonationSnapshot())
+	for {
+		select {
+		case <-ticker.C:
+		case <-final.C:
+			final.Reset(untilFinalDonationSnapshot())
+		}
+
+		clans, err := h.clans.AllClans()
+		if err != nil {
+			slog.Error("Error while getting clans to track donations.", slog.Any("err", err))
+			continue
+		}
+
+		for _, clan := range clans {
+			if err = h.storeDonationSnapshots(clan.Tag); err != nil {
+				slog.Error("Error while storing donation snapshots.", slog.Any("err", err), slog.String("clanTag", clan.Tag))
+			}
+		}
+	}
+}
+
+// untilFinalDonationSnapshot returns the duration until the last snapshot of the current season is due.
+func untilFinalDonationSnapshot() time.Duration {
+	end := util.NextDonationSeasonEnd(time.Now().Add(donationFinalSnapshotLead))
+	return time.Until(end.Add(-donationFinalSnapshotLead))
+}
+
+func (h *ClanHandler) storeDonationSnapshots(clanTag string) error {
+	members, err := h.members.MembersByClanTag(clanTag)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	// accounts which could not be loaded keep their last snapshot
+	players := slices.DeleteFunc(h.clashClient.GetPlayers(members.Tags()...), func(player *goclash.Player) bool {
+		return player == nil
+	})
+	if len(players) == 0 {
+		return nil
+	}
+
+	// taken after fetching, so that values fetched after the reset are never saved to the previous season.
+	// Values from before the reset saved to the new season are overwritten by the next snapshot.
+	season := util.DonationSeason(time.Now())
+
+	snapshots := make([]*models.DonationSnapshot, len(players))
+	for index, player := range players {
+		snapshots[index] = &models.DonationSnapshot{
+			Season:            season,
+			PlayerTag:         player.Tag,
+			ClanTag:           clanTag,
+			Name:              player.Name,
+			Donations:         player.Donations,
+			DonationsReceived: player.DonationsReceived,
+		}
+	}
+	return h.donations.SaveDonationSnapshots(snapshots)
+}
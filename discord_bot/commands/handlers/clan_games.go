@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	clanGamesTrackInterval    = time.Hour
+	clanGamesProgressInterval = time.Hour * 24
+
+	// clanGamesStartTolerance is how long after the start a start value is still exact enough to flag the member.
+	clanGamesStartTolerance = time.Minute * 10
+)
+
+func (h *ClanHandler) ClanGamesSettings(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	minPoints := util.IntOptionByName(MinPointsOptionName, opts)
+	if clanTag == "" || minPoints == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und das Minimum an Punkten an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	settings.ClanGamesMinPoints = *minPoints
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.ClanGamesSettingsEmbed(clanName, settings))
+}
+
+// trackClanGames periodically follows the Clan Games of every clan with a clan games channel.
+// It takes a snapshot of the Games Champion achievement of every member right at the start, posts the progress once a day
+// and posts the final table at the end. Members below the minimum of the clan are reported in the leader channel.
+// If the snapshot is taken late, e.g. because the bot was offline at the start, these members are marked instead of reported.
+func (h *ClanHandler) trackClanGames() {
+	ticker := time.NewTicker(clanGamesTrackInterval)
+	start := time.NewTimer(time.Until(util.NextClanGamesStart(time.Now())))
+	for {
+		select {
+		case <-ticker.C:
+		case <-start.C:
+			start.Reset(time.Until(util.NextClanGamesStart(time.Now())))
+		}
+
+		settings, err := h.clanSettings.ClanSettingsWithChannel(models.ClanChannelClanGames)
+		if err != nil {
+			slog.Error("Error while getting clans with clan games channel.", slog.Any("err", err))
+			continue
+		}
+
+		for _, s := range settings {
+			if err = h.trackClanGamesOfClan(s); err != nil {
+				slog.Error("Error while tracking clan games.", slog.Any("err", err), slog.String("clanTag", s.ClanTag))
+			}
+		}
+	}
+}
+
+func (h *ClanHandler) trackClanGamesOfClan(settings *models.ClanSettings) error {
+	games, err := h.clanGames.LatestClanGames(settings.ClanTag)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// gorm fills the pointer with empty Clan Games if none are stored yet
+		games = nil
+	} else if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	start, end := util.ClanGamesWindow(now)
+	if !now.Before(start) && now.Before(end) && (games == nil || !games.StartTime.Equal(start)) {
+		return h.startClanGames(settings, start, end)
+	}
+	if games == nil || games.FinishedAt != nil {
+		return nil
+	}
+
+	members, err := h.refreshClanGamesMembers(games)
+	if err != nil {
+		return err
+	}
+
+	clanName := settings.ClanTag
+	if settings.Clan != nil {
+		clanName = settings.Clan.Name
+	}
+
+	// saved before posting, so that the result is not posted twice if saving fails
+	if !now.Before(games.EndTime) {
+		games.FinishedAt = &now
+		if err = h.clanGames.SaveClanGames(games); err != nil {
+			return err
+		}
+
+		messages.SendChannelEmbed(settings.ClanGamesChannelID, messages.ClanGamesResultEmbed(clanName, members, settings.ClanGamesMinPoints))
+		h.flagClanGamesMembers(settings, clanName, members)
+		return nil
+	}
+
+	if games.LastProgressAt == nil || now.Sub(*games.LastProgressAt) >= clanGamesProgressInterval {
+		messages.SendChannelEmbed(settings.ClanGamesChannelID, messages.ClanGamesProgressEmbed(clanName, games, members, settings.ClanGamesMinPoints))
+		games.LastProgressAt = &now
+	}
+	return h.clanGames.SaveClanGames(games)
+}
+
+// startClanGames takes the snapshot of the members at the start of the Clan Games.
+func (h *ClanHandler) startClanGames(settings *models.ClanSettings, start, end time.Time) error {
+	games := &models.ClanGames{
+		ClanTag:   settings.ClanTag,
+		StartTime: start,
+		EndTime:   end,
+	}
+	if _, err := h.refreshClanGamesMembers(games); err != nil {
+		return err
+	}
+
+	// the progress is posted for the first time a day after the start
+	now := time.Now()
+	games.LastProgressAt = &now
+	if err := h.clanGames.SaveClanGames(games); err != nil {
+		return err
+	}
+
+	clanName := settings.ClanTag
+	if settings.Clan != nil {
+		clanName = settings.Clan.Name
+	}
+	messages.SendChannelEmbed(settings.ClanGamesChannelID, messages.ClanGamesStartEmbed(clanName, games, settings.ClanGamesMinPoints))
+	return nil
+}
+
+// refreshClanGamesMembers updates the points of the current members of the clan. Members who joined during the Clan Games start with their current value,
+// members added well after the start are marked as late.
+// Members whose account could not be loaded keep their last value and are added once it can be loaded.
+// Only the current members are returned, members who left are kept in the Clan Games but not reported anymore.
+func (h *ClanHandler) refreshClanGamesMembers(games *models.ClanGames) ([]*models.ClanGamesMember, error) {
+	clanMembers, err := h.members.MembersByClanTag(games.ClanTag)
+	if err != nil {
+		return nil, err
+	}
+	if len(clanMembers) == 0 {
+		return nil, nil
+	}
+
+	players := slices.DeleteFunc(h.clashClient.GetPlayers(clanMembers.Tags()...), func(player *goclash.Player) bool {
+		return player == nil
+	})
+	if len(players) == 0 {
+		return nil, errors.New("no member of the clan could be loaded")
+	}
+	values, err := util.StatisticValueFromPlayers(players, types.StatClanGamesPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	memberByTag := make(map[string]*models.ClanGamesMember, len(games.Members))
+	for _, member := range games.Members {
+		memberByTag[member.PlayerTag] = member
+	}
+
+	for index, player := range players {
+		member, ok := memberByTag[player.Tag]
+		if !ok {
+			member = &models.ClanGamesMember{
+				PlayerTag:   player.Tag,
+				StartPoints: values[index],
+				LateStart:   time.Since(games.StartTime) > clanGamesStartTolerance,
+			}
+			memberByTag[player.Tag] = member
+			games.Members = append(games.Members, member)
+		}
+		member.Name = player.Name
+		member.Points = values[index]
+	}
+
+	members := make([]*models.ClanGamesMember, 0, len(clanMembers))
+	for _, clanMember := range clanMembers {
+		if member, ok := memberByTag[clanMember.PlayerTag]; ok {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// flagClanGamesMembers reports the members below the minimum in the leader channel. Absent members and members with a late start value are not flagged.
+func (h *ClanHandler) flagClanGamesMembers(settings *models.ClanSettings, clanName string, members []*models.ClanGamesMember) {
+	if settings.ClanGamesMinPoints <= 0 {
+		return
+	}
+
+	var below []*models.ClanGamesMember
+	for _, member := range members {
+		if !member.LateStart && member.Earned() < settings.ClanGamesMinPoints {
+			below = append(below, member)
+		}
+	}
+	if len(below) == 0 {
+		return
+	}
+
+	tags := make([]string, len(below))
+	for index, member := range below {
+		tags[index] = member.PlayerTag
+	}
+	absenceByTag := activeAbsences(h.absences, tags)
+	below = slices.DeleteFunc(below, func(member *models.ClanGamesMember) bool {
+		_, absent := absenceByTag[member.PlayerTag]
+		return absent
+	})
+	if len(below) == 0 {
+		return
+	}
+
+	channelID := settings.ChannelID(models.ClanChannelLeader)
+	if channelID == "" {
+		slog.Warn("No leader channel set, clan games flags were not sent.", slog.String("clanTag", settings.ClanTag))
+		return
+	}
+	messages.SendChannelEmbed(channelID, messages.ClanGamesFlagEmbed(clanName, below, settings.ClanGamesMinPoints))
+}
@@ -27,27 +27,48 @@ import (
 type IClanHandler interface {
 	ClanStats(s *discordgo.Session, i *discordgo.InteractionCreate)
 	RaidPing(s *discordgo.Session, i *discordgo.InteractionCreate)
+	RaidReminders(s *discordgo.Session, i *discordgo.InteractionCreate)
+	RaidStats(s *discordgo.Session, i *discordgo.InteractionCreate)
+	ClanGamesSettings(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Donations(s *discordgo.Session, i *discordgo.InteractionCreate)
+	DonationSettings(s *discordgo.Session, i *discordgo.InteractionCreate)
 	EventInfo(s *discordgo.Session, i *discordgo.InteractionCreate)
 	CreateEvent(s *discordgo.Session, i *discordgo.InteractionCreate)
 	DeleteEvent(s *discordgo.Session, i *discordgo.InteractionCreate)
 	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
 	CWDonator(s *discordgo.Session, i *discordgo.InteractionCreate)
+	CWDonatorComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
+	CWDonorPreference(s *discordgo.Session, i *discordgo.InteractionCreate)
 }
 
 type ClanHandler struct {
 	clans          repos.IClansRepo
 	members        repos.IMembersRepo
+	players        repos.IPlayersRepo
+	clanSettings   repos.IClanSettingsRepo
 	events         repos.IClanEventsRepo
+	absences       repos.IAbsencesRepo
+	cwDonors       repos.ICWDonorsRepo
+	raids          repos.IRaidsRepo
+	clanGames      repos.IClanGamesRepo
+	donations      repos.IDonationsRepo
 	clashClient    *goclash.Client
 	auth           middleware.AuthMiddleware
 	eventCancelers cmap.ConcurrentMap[string, context.CancelFunc]
 }
 
-func NewClanHandler(clans repos.IClansRepo, members repos.IMembersRepo, events repos.IClanEventsRepo, auth middleware.AuthMiddleware, clashClient *goclash.Client) IClanHandler {
+func NewClanHandler(clans repos.IClansRepo, members repos.IMembersRepo, players repos.IPlayersRepo, clanSettings repos.IClanSettingsRepo, events repos.IClanEventsRepo, absences repos.IAbsencesRepo, cwDonors repos.ICWDonorsRepo, raids repos.IRaidsRepo, clanGames repos.IClanGamesRepo, donations repos.IDonationsRepo, auth middleware.AuthMiddleware, clashClient *goclash.Client) IClanHandler {
 	h := &ClanHandler{
 		clans:          clans,
 		members:        members,
+		players:        players,
+		clanSettings:   clanSettings,
 		events:         events,
+		absences:       absences,
+		cwDonors:       cwDonors,
+		raids:          raids,
+		clanGames:      clanGames,
+		donations:      donations,
 		clashClient:    clashClient,
 		auth:           auth,
 		eventCancelers: cmap.New[context.CancelFunc](),
@@ -61,12 +82,16 @@ func NewClanHandler(clans repos.IClansRepo, members repos.IMembersRepo, events r
 		go h.watchEvent(event)
 	}
 
+	go h.remindRaids()
+	go h.trackRaids()
+	go h.trackClanGames()
+	go h.trackDonations()
+
 	return h
 }
 
 func (h *ClanHandler) CWDonator(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	var noPings bool
-	noPings = false
 
 	slog.Info("Received interaction", slog.Any("interactionID", i.ID), slog.Any("interactionType", i.Type))
 
@@ -88,6 +113,7 @@ func (h *ClanHandler) CWDonator(s *discordgo.Session, i *discordgo.InteractionCr
 		return
 	}
 
+	// members may look at a selection, but only co-leaders ping the donors. The response tells members about it.
 	if err = h.auth.AuthorizeInteractionWithoutMessageEditNoClanNeeded(s, i, clanTag, types.AuthRoleCoLeader); err != nil {
 		noPings = true
 	}
@@ -110,41 +136,24 @@ func (h *ClanHandler) CWDonator(s *discordgo.Session, i *discordgo.InteractionCr
 		return
 	}
 
-	clanWarPlayerTags := make([]string, len(clanWar.Clan.Members))
-	for index, member := range clanWar.Clan.Members {
-		clanWarPlayerTags[index] = member.Tag
+	if len(clanWar.Clan.Members) == 0 {
+		if err = messages.CreateAndEditEmbed(s, i, "Kein Clan Krieg", fmt.Sprintf("Der Clan '%s' befindet sich aktuell in keinem Clan Krieg.", clanName), messages.ColorRed); err != nil {
+			slog.Error("Failed to edit message.", slog.Any("err", err))
+		}
+		return
 	}
 
-	var members []*models.ClanMember
-
-	if noPings == false {
-		_, err = h.clans.ClanByTag(clanTag)
-		if err == nil {
-			members, err = h.members.MembersByClanTag(clanTag)
-			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					if err = messages.CreateAndEditEmbed(s, i, "Clan hat keine Mitglieder", fmt.Sprintf("Der Clan '%s' hat keine Mitglieder.", clanName), messages.ColorRed); err != nil {
-						slog.Error("Failed to edit message.", slog.Any("err", err))
-					}
-					return
-				}
-
-				if err = messages.CreateAndEditEmbed(s, i, "Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed); err != nil {
-					slog.Error("Failed to edit message.", slog.Any("err", err))
-				}
-				return
-			}
-		} else {
-			for i := 0; i < len(clanWarPlayerTags); i++ {
-				member, _ := h.members.MembersByPlayerTag(clanWarPlayerTags[i])
-				if member != nil {
-					members = append(members, member...)
-				}
+	var members models.ClanMembers
+	if !noPings {
+		if members, err = h.cwDonatorMembers(clanTag, clanWar.Clan.Members); err != nil {
+			if err = messages.CreateAndEditEmbed(s, i, "Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed); err != nil {
+				slog.Error("Failed to edit message.", slog.Any("err", err))
 			}
+			return
 		}
 	}
 
-	clanPlayers, err := h.clashClient.GetPlayersWithError(clanWarPlayerTags...)
+	candidates, err := cwDonorCandidates(h.clashClient, h.cwDonors, h.absences, clanTag, clanWar.Clan.Members, members)
 	if err != nil {
 		if err = messages.CreateAndEditEmbed(s, i, "Fehler", "Beim Abrufen der Kriegs Teilnehmer ist ein Fehler aufgetreten.", messages.ColorRed); err != nil {
 			slog.Error("Failed to edit message.", slog.Any("err", err))
@@ -152,20 +161,28 @@ func (h *ClanHandler) CWDonator(s *discordgo.Session, i *discordgo.InteractionCr
 		return
 	}
 
-	clanPlayerByTag := make(map[string]*goclash.Player, len(clanPlayers))
-	for _, clanPlayer := range clanPlayers {
-		clanPlayerByTag[clanPlayer.Tag] = clanPlayer
+	donors := util.SelectCWDonors(len(clanWar.Clan.Members), h.cwDonorRangeSize(clanTag), candidates)
+
+	// only selections of co-leaders are saved, so members can't influence the rotation
+	var components []discordgo.MessageComponent
+	if !noPings && len(donors) > 0 {
+		if err = saveCWDonors(h.cwDonors, clanTag, clanWar.PreparationStartTime, donors); err != nil {
+			slog.Error("Error while saving cw donor assignments.", slog.Any("err", err))
+		} else {
+			components = cwDonorRerollButtons(clanTag, clanWar.PreparationStartTime, donors)
+		}
 	}
 
 	if _, err = util.Session.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Embeds: &[]*discordgo.MessageEmbed{
-			messages.NewEmbed("CW Spender", "Folgende Mitglieder wurden zufällig als Spender ausgewählt:", messages.ColorAqua),
-		},
+		Embeds: &[]*discordgo.MessageEmbed{messages.CWDonatorEmbed(noPings)},
 	}); err != nil {
 		slog.Error("Failed to edit message.", slog.Any("err", err))
 	}
 
-	if _, err = util.Session.ChannelMessageSend(i.ChannelID, messages.CWDonatorPing(members, clanWar.Clan.Members, clanPlayerByTag)); err != nil {
+	if _, err = util.Session.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+		Content:    messages.CWDonatorPing(donors),
+		Components: components,
+	}); err != nil {
 		slog.Error("Error sending message", slog.Any("err", err))
 	}
 }
@@ -268,7 +285,7 @@ func (h *ClanHandler) RaidPing(_ *discordgo.Session, i *discordgo.InteractionCre
 		return
 	}
 
-	messages.SendRaidPing(i, members, raid.Items[0])
+	messages.SendRaidPing(i, withoutAbsentMembers(h.absences, members), raid.Items[0])
 }
 
 func (h *ClanHandler) EventInfo(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -642,6 +659,8 @@ func (h *ClanHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.Inte
 		switch opt.Name {
 		case ClanTagOptionName:
 			autocompleteClans(i, h.clans, opt.StringValue())
+		case MyPlayerTagOptionName:
+			autocompleteMyPlayers(i, h.players, opt.StringValue())
 		}
 	}
 }
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/commands/validation"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	raidTrackInterval   = time.Hour
+	defaultRaidWeekends = 4
+
+	// raidHistoryLimit is the number of raid weekends fetched for clans without stored weekends, later on only the latest ones are fetched.
+	raidHistoryLimit = 10
+	raidRecentLimit  = 2
+)
+
+func (h *ClanHandler) RaidReminders(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	times := strings.TrimSpace(util.StringOptionByName(TimesOptionName, opts))
+	if clanTag == "" || times == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und die Zeiten der Erinnerungen an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	// 0 disables the reminders
+	if times == "0" {
+		times = ""
+	} else if msg, ok := validation.ValidateRaidReminderTimes(times); !ok {
+		messages.SendInvalidInputErr(i, msg)
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	settings.RaidReminderTimes = times
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.RaidSettingsEmbed(clanName, settings))
+}
+
+func (h *ClanHandler) RaidStats(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	weekendCount := defaultRaidWeekends
+	if count := util.IntOptionByName(WeekendsOptionName, opts); count != nil {
+		weekendCount = *count
+	}
+
+	weekends, err := h.raids.RaidWeekends(clanTag, weekendCount, "Members")
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	// members who skipped a whole weekend are not part of the weekend, so they are taken from the member history
+	var history []*models.MemberHistory
+	names := make(map[string]string)
+	if len(weekends) > 0 {
+		history, err = h.members.MemberHistorySince(clanTag, weekends[len(weekends)-1].EndTime)
+		if err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+
+		tags := make([]string, len(history))
+		for index, membership := range history {
+			tags[index] = membership.PlayerTag
+		}
+		players, err := h.players.PlayersByTags(tags...)
+		if err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+		for _, player := range players {
+			names[player.CocTag] = player.Name
+		}
+	}
+
+	messages.SendEmbedResponse(i, messages.RaidStatsEmbed(clanName, weekends, util.RaidPlayerStatistics(weekends, history, names)))
+}
+
+// trackRaids periodically stores the finished raid weekends of every clan.
+func (h *ClanHandler) trackRaids() {
+	for range time.Tick(raidTrackInterval) {
+		clans, err := h.clans.AllClans()
+		if err != nil {
+			slog.Error("Error while getting clans to track raids.", slog.Any("err", err))
+			continue
+		}
+
+		for _, clan := range clans {
+			if err = h.storeRaidWeekends(clan.Tag); err != nil {
+				slog.Error("Error while storing raid weekends.", slog.Any("err", err), slog.String("clanTag", clan.Tag))
+			}
+		}
+	}
+}
+
+// storeRaidWeekends saves the finished raid weekends of the clan which are not stored yet.
+func (h *ClanHandler) storeRaidWeekends(clanTag string) error {
+	startTimes, err := h.raids.RaidWeekendStartTimes(clanTag)
+	if err != nil {
+		return err
+	}
+
+	limit := raidRecentLimit
+	if len(startTimes) == 0 {
+		limit = raidHistoryLimit
+	}
+
+	seasons, err := h.clashClient.GetClanCapitalRaidSeasons(clanTag, &goclash.PagingParams{
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, season := range seasons.Items {
+		if season.State != util.RaidSeasonEnded {
+			continue
+		}
+
+		weekend, err := util.NewRaidWeekend(clanTag, season)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(startTimes, weekend.StartTime.Equal) {
+			continue
+		}
+
+		if err = h.raids.SaveRaidWeekend(weekend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remindRaids checks every minute whether a raid reminder of a clan with a raid channel is due and pings the members with unused attacks.
+func (h *ClanHandler) remindRaids() {
+	for now := range time.Tick(time.Minute) {
+		settings, err := h.clanSettings.ClanSettingsWithChannel(models.ClanChannelRaid)
+		if err != nil {
+			slog.Error("Error while getting clans with raid channel.", slog.Any("err", err))
+			continue
+		}
+
+		for _, s := range settings {
+			if !slices.ContainsFunc(s.RaidReminders(), func(t models.RaidReminderTime) bool { return t.Matches(now) }) {
+				continue
+			}
+			if err = h.sendRaidReminder(s); err != nil {
+				slog.Error("Error while sending raid reminder.", slog.Any("err", err), slog.String("clanTag", s.ClanTag))
+			}
+		}
+	}
+}
+
+// sendRaidReminder runs the analysis of /raidping and posts it in the raid channel. Nothing is sent if the raid weekend is over or nobody has attacks left.
+func (h *ClanHandler) sendRaidReminder(settings *models.ClanSettings) error {
+	raid, err := h.clashClient.GetClanCapitalRaidSeasons(settings.ClanTag, &goclash.PagingParams{
+		Limit: 1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(raid.Items) == 0 || raid.Items[0].State != util.RaidSeasonOngoing {
+		return nil
+	}
+
+	members, err := h.members.MembersByClanTag(settings.ClanTag)
+	if err != nil {
+		return err
+	}
+
+	content := messages.RaidReminder(withoutAbsentMembers(h.absences, members), raid.Items[0])
+	if content == "" {
+		return nil
+	}
+
+	_, err = util.Session.ChannelMessageSend(settings.RaidChannelID, content)
+	return err
+}
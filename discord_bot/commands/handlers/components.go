@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/messages"
+)
+
+// authorizeComponentUser checks if the component was used by the user it was created for and sends an error if not.
+func authorizeComponentUser(i *discordgo.InteractionCreate, userID string) bool {
+	if i.Member.User.ID == userID {
+		return true
+	}
+
+	messages.SendEphemeralEmbedResponse(i, messages.NewEmbed(
+		"Keine Berechtigung",
+		"Nur die Person, die den Befehl ausgeführt hat, kann diese Aktion ausführen.",
+		messages.ColorRed,
+	))
+	return false
+}
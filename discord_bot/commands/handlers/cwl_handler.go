@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/components"
+	"bot/commands/messages"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	cwlSignupCommandName = "cwlsignup" // component ids of the signup buttons are routed to this command
+
+	openCWLSignupAction   = "open"
+	toggleCWLSignupAction = "toggle"
+	preferCWLClanAction   = "prefer"
+
+	defaultCWLRosterSize = 15
+
+	// default points of the CWL bonus ranking
+	defaultCWLStarPoints         = 10
+	defaultCWLDestructionPoints  = 10
+	defaultCWLAttackPoints       = 5
+	defaultCWLTownHallDiffPoints = 5
+)
+
+type ICWLHandler interface {
+	CWLSignup(s *discordgo.Session, i *discordgo.InteractionCreate)
+	CWLSignupComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
+	CWLPlan(s *discordgo.Session, i *discordgo.InteractionCreate)
+	CWLGroup(s *discordgo.Session, i *discordgo.InteractionCreate)
+	CWLBonus(s *discordgo.Session, i *discordgo.InteractionCreate)
+	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
+}
+
+type CWLHandler struct {
+	clans       repos.IClansRepo
+	players     repos.IPlayersRepo
+	members     repos.IMembersRepo
+	cwl         repos.ICWLRepo
+	auth        middleware.AuthMiddleware
+	clashClient *goclash.Client
+}
+
+func NewCWLHandler(clans repos.IClansRepo, players repos.IPlayersRepo, members repos.IMembersRepo, cwl repos.ICWLRepo, auth middleware.AuthMiddleware, clashClient *goclash.Client) ICWLHandler {
+	return &CWLHandler{
+		clans:       clans,
+		players:     players,
+		members:     members,
+		cwl:         cwl,
+		auth:        auth,
+		clashClient: clashClient,
+	}
+}
+
+func (h *CWLHandler) CWLSignup(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	season, ok := cwlSeasonOption(i)
+	if !ok {
+		return
+	}
+
+	if !h.isLeader(i) {
+		return
+	}
+
+	messages.SendComponentsResponse(i, messages.CWLSignupEmbed(season), components.ButtonRow(discordgo.Button{
+		Label:    "An-/Abmelden",
+		Style:    discordgo.PrimaryButton,
+		CustomID: util.BuildComponentID(cwlSignupCommandName, "", openCWLSignupAction, season),
+	}))
+}
+
+func (h *CWLHandler) CWLSignupComponent(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, userID, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	switch {
+	case action == openCWLSignupAction && len(values) == 1:
+		h.showCWLSignups(i, values[0], false)
+	case action == toggleCWLSignupAction && len(values) == 2 && userID == i.Member.User.ID:
+		h.toggleCWLSignup(i, values[0], values[1])
+	case action == preferCWLClanAction && len(values) == 1 && userID == i.Member.User.ID:
+		h.preferCWLClan(i, values[0])
+	default:
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+	}
+}
+
+func (h *CWLHandler) CWLPlan(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	season, ok := cwlSeasonOption(i)
+	if !ok {
+		return
+	}
+
+	if !h.isLeader(i) {
+		return
+	}
+
+	rosterSize := defaultCWLRosterSize
+	if size := util.IntOptionByName(RosterSizeOptionName, i.ApplicationCommandData().Options); size != nil {
+		rosterSize = *size
+	}
+
+	signups, err := h.cwl.CWLSignups(season)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	if len(signups) == 0 {
+		messages.SendErr(i, fmt.Sprintf("Für die CWL %s hat sich noch niemand angemeldet.", season))
+		return
+	}
+
+	clans, err := h.clans.AllClans()
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	rosters, unassigned := util.PlanCWLRosters(signups, clans, rosterSize)
+	if err = h.cwl.SaveCWLAssignments(signups); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	file, err := messages.CWLRostersFile(season, rosters, unassigned)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	messages.SendFileResponse(i, messages.CWLRostersEmbed(season, rosters, unassigned, rosterSize), file)
+}
+
+func (h *CWLHandler) CWLGroup(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	clanTag := util.StringOptionByName(ClanTagOptionName, i.ApplicationCommandData().Options)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	group, ok := h.currentCWLGroup(i, clanTag)
+	if !ok {
+		return
+	}
+
+	// every drawn round needs up to four requests to find the war of the clan
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		slog.Error("Failed to send deferred response", slog.Any("err", err))
+		return
+	}
+
+	wars, err := h.clanCWLWars(group, clanTag)
+	if err != nil {
+		sendCWLWarsErr(s, i, err)
+		return
+	}
+
+	if _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{messages.CWLGroupEmbed(group, clanTag, wars)},
+	}); err != nil {
+		slog.Error("Failed to edit message.", slog.Any("err", err))
+	}
+}
+
+func (h *CWLHandler) CWLBonus(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	bonuses := util.IntOptionByName(BonusesOptionName, opts)
+	if bonuses == nil || *bonuses < 1 {
+		messages.SendInvalidInputErr(i, "Bitte gib die Anzahl der verfügbaren Boni an.")
+		return
+	}
+
+	weights := types.CWLBonusWeights{
+		Star:         intOptionOrDefault(StarsOptionName, opts, defaultCWLStarPoints),
+		Destruction:  intOptionOrDefault(DestructionOptionName, opts, defaultCWLDestructionPoints),
+		Attack:       intOptionOrDefault(AttacksOptionName, opts, defaultCWLAttackPoints),
+		TownHallDiff: intOptionOrDefault(TownHallDiffOptionName, opts, defaultCWLTownHallDiffPoints),
+	}
+
+	group, ok := h.currentCWLGroup(i, clanTag)
+	if !ok {
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		slog.Error("Failed to send deferred response", slog.Any("err", err))
+		return
+	}
+
+	wars, err := h.clanCWLWars(group, clanTag)
+	if err != nil {
+		sendCWLWarsErr(s, i, err)
+		return
+	}
+
+	stats := util.CWLMemberStatistics(wars)
+	if len(stats) == 0 {
+		if err = messages.CreateAndEditEmbed(s, i, "Keine Angriffe", "In der aktuellen CWL hat noch kein Kampftag begonnen.", messages.ColorRed); err != nil {
+			slog.Error("Failed to edit message.", slog.Any("err", err))
+		}
+		return
+	}
+
+	clanName := clanTag
+	for _, clan := range group.Clans {
+		if clan.Tag == clanTag {
+			clanName = clan.Name
+		}
+	}
+
+	if _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{messages.CWLBonusEmbed(clanName, group.Season, util.CWLBonusRanking(stats, weights), *bonuses, weights)},
+	}); err != nil {
+		slog.Error("Failed to edit message.", slog.Any("err", err))
+	}
+}
+
+func (h *CWLHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	for _, opt := range i.ApplicationCommandData().Options {
+		if !opt.Focused {
+			continue
+		}
+
+		switch opt.Name {
+		case ClanTagOptionName:
+			autocompleteClans(i, h.clans, opt.StringValue())
+		}
+	}
+}
+
+// showCWLSignups shows the linked accounts of the user with a button to toggle the signup of each account.
+func (h *CWLHandler) showCWLSignups(i *discordgo.InteractionCreate, season string, update bool) {
+	players, err := h.players.PlayersByDiscordID(i.Member.User.ID)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	if len(players) == 0 {
+		messages.SendEphemeralEmbedResponse(i, messages.NewEmbed("Keine Accounts", "Mit deinem Discord Account sind keine Accounts verknüpft. Nutze `/verify`, um einen Account zu verknüpfen.", messages.ColorRed))
+		return
+	}
+
+	signups, err := h.cwl.CWLSignupsByDiscordID(season, i.Member.User.ID)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	signedUp := make(map[string]bool, len(signups))
+	for _, signup := range signups {
+		signedUp[signup.PlayerTag] = true
+	}
+
+	clans, err := h.clans.AllClans()
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	preferredClanTag, preferredClanName := cwlPreference(signups), ""
+	for _, clan := range clans {
+		if clan.Tag == preferredClanTag {
+			preferredClanName = clan.Name
+		}
+	}
+
+	embed := messages.CWLSignupAccountsEmbed(season, players, signedUp, preferredClanName)
+	buttons := components.CWLSignupButtons(players, signedUp, func(player *models.Player) string {
+		return util.BuildComponentID(cwlSignupCommandName, i.Member.User.ID, toggleCWLSignupAction, season, player.CocTag)
+	})
+	if len(signups) > 0 {
+		buttons = append(buttons, components.CWLPreferenceSelect(clans, preferredClanTag,
+			util.BuildComponentID(cwlSignupCommandName, i.Member.User.ID, preferCWLClanAction, season),
+		)...)
+	}
+
+	if update {
+		messages.UpdateComponents(i, embed, buttons)
+		return
+	}
+	messages.SendEphemeralComponentsResponse(i, embed, buttons)
+}
+
+func (h *CWLHandler) toggleCWLSignup(i *discordgo.InteractionCreate, season, playerTag string) {
+	if _, err := h.players.PlayerByTagAndDiscordID(playerTag, i.Member.User.ID); err != nil {
+		messages.SendErr(i, fmt.Sprintf("Der Account %s ist nicht mehr mit deinem Discord Account verknüpft.", playerTag))
+		return
+	}
+
+	signups, err := h.cwl.CWLSignupsByDiscordID(season, i.Member.User.ID)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	signedUp := false
+	for _, signup := range signups {
+		signedUp = signedUp || signup.PlayerTag == playerTag
+	}
+
+	if signedUp {
+		if err = h.cwl.DeleteCWLSignup(season, playerTag); err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+	} else {
+		player, err := h.clashClient.GetPlayer(playerTag)
+		if err != nil {
+			messages.SendCocApiErr(i, err)
+			return
+		}
+
+		// the preference chosen for the other accounts applies to new signups as well, otherwise the current clan is preferred
+		signup := &models.CWLSignup{
+			Season:        season,
+			PlayerTag:     playerTag,
+			TownHallLevel: player.TownHallLevel,
+		}
+		if clanTag := cwlPreference(signups); clanTag != "" {
+			signup.PreferredClanTag = &clanTag
+		} else if members, err := h.members.MembersByPlayerTag(playerTag); err == nil && len(members) > 0 {
+			signup.PreferredClanTag = &members[0].ClanTag
+		}
+		if err = h.cwl.CreateCWLSignup(signup); err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+	}
+
+	h.showCWLSignups(i, season, true)
+}
+
+// preferCWLClan sets the clan chosen in the select menu as preferred clan of all signed up accounts of the user.
+func (h *CWLHandler) preferCWLClan(i *discordgo.InteractionCreate, season string) {
+	values := i.MessageComponentData().Values
+	if len(values) != 1 {
+		messages.SendInvalidInputErr(i, "Bitte wähle einen Clan aus.")
+		return
+	}
+
+	var clanTag *string
+	if values[0] != components.NoCWLPreference {
+		if _, err := h.clans.ClanNameByTag(values[0]); err != nil {
+			messages.SendClanNotFound(i, values[0])
+			return
+		}
+		clanTag = &values[0]
+	}
+
+	if err := h.cwl.UpdateCWLPreference(season, i.Member.User.ID, clanTag); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	h.showCWLSignups(i, season, true)
+}
+
+// cwlPreference returns the preferred clan of the signups of a user, or an empty string if there is none.
+func cwlPreference(signups []*models.CWLSignup) string {
+	for _, signup := range signups {
+		if signup.PreferredClanTag != nil {
+			return *signup.PreferredClanTag
+		}
+	}
+	return ""
+}
+
+// isLeader checks if the user is at least co-leader in any clan and sends an error message if not.
+func (h *CWLHandler) isLeader(i *discordgo.InteractionCreate) bool {
+	clanTags, err := h.auth.ClanTagsWithRole(i, types.AuthRoleCoLeader)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return false
+	}
+	if len(clanTags) == 0 {
+		messages.SendErr(i, "Nur Vize-Anführer und Anführer können die CWL planen.")
+		return false
+	}
+	return true
+}
+
+// cwlSeasonOption returns the season given in the options, or the season of the next CWL. Sends an error message if the season is invalid.
+func cwlSeasonOption(i *discordgo.InteractionCreate) (string, bool) {
+	season := util.StringOptionByName(SeasonOptionName, i.ApplicationCommandData().Options)
+	if season == "" {
+		return util.CWLSeason(time.Now()), true
+	}
+	if !util.ValidCWLSeason(season) {
+		messages.SendInvalidInputErr(i, "Die Season muss im Format `YYYY-MM` angegeben werden, z.B. `2024-03`.")
+		return "", false
+	}
+	return season, true
+}
+
+// currentCWLGroup returns the current CWL group of the clan. If the clan is not in the CWL, an error response is sent.
+func (h *CWLHandler) currentCWLGroup(i *discordgo.InteractionCreate, clanTag string) (*goclash.ClanWarLeagueGroup, bool) {
+	group, err := h.clashClient.GetCurrentClanWarLeagueGroup(clanTag)
+	if err != nil {
+		messages.SendCocApiErr(i, err)
+		return nil, false
+	}
+	if group.State == goclash.ClanWarLeagueGroupStateNotFound || group.State == goclash.ClanWarLeagueGroupStateNotInWar || len(group.Clans) == 0 {
+		messages.SendErr(i, "Der Clan nimmt aktuell nicht an der CWL teil.")
+		return nil, false
+	}
+	return group, true
+}
+
+// clanCWLWars returns the war of the clan in every drawn round of the group, seen from the clan's side.
+func (h *CWLHandler) clanCWLWars(group *goclash.ClanWarLeagueGroup, clanTag string) ([]*goclash.ClanWar, error) {
+	var wars []*goclash.ClanWar
+	for _, round := range group.Rounds {
+		if !util.CWLRoundDrawn(round) {
+			continue
+		}
+
+		for _, warTag := range round.WarTags {
+			war, err := h.clashClient.GetClanWarLeagueWar(warTag)
+			if err != nil {
+				return nil, err
+			}
+			if war = util.CWLWarOfClan(war, clanTag); war != nil {
+				wars = append(wars, war)
+				break
+			}
+		}
+	}
+	return wars, nil
+}
+
+func sendCWLWarsErr(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	slog.Error("Error while getting CWL wars.", slog.Any("err", err))
+	if err = messages.CreateAndEditEmbed(s, i, "API Fehler", "Die Kriege der CWL konnten nicht abgerufen werden.", messages.ColorRed); err != nil {
+		slog.Error("Failed to edit message.", slog.Any("err", err))
+	}
+}
+
+// intOptionOrDefault returns the value of the integer option, or the default if the option is not set.
+func intOptionOrDefault(name string, opts []*discordgo.ApplicationCommandInteractionDataOption, defaultValue int) int {
+	if value := util.IntOptionByName(name, opts); value != nil {
+		return *value
+	}
+	return defaultValue
+}
@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/components"
+	"bot/commands/messages"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/env"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	leftMembersCommandName = "leftmembers" // component ids of the leader notifications are routed to this command
+
+	removeMembershipsAction = "remove"
+	keepMembershipsAction   = "keep"
+	restoreRolesAction      = "restore"
+	ignoreRejoinAction      = "ignore"
+)
+
+type IGuildMemberHandler interface {
+	LeftMembers(s *discordgo.Session, i *discordgo.InteractionCreate)
+	LeftMembersComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
+	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
+	OnGuildMemberRemove(s *discordgo.Session, e *discordgo.GuildMemberRemove)
+	OnGuildMemberAdd(s *discordgo.Session, e *discordgo.GuildMemberAdd)
+}
+
+type GuildMemberHandler struct {
+	players      repos.IPlayersRepo
+	members      repos.IMembersRepo
+	clans        repos.IClansRepo
+	guilds       repos.IGuildsRepo
+	clanSettings repos.IClanSettingsRepo
+	kickpoints   repos.IKickpointsRepo
+	blacklist    repos.IBlacklistRepo
+	nicknames    repos.INicknamesRepo
+	auth         middleware.AuthMiddleware
+}
+
+func NewGuildMemberHandler(players repos.IPlayersRepo, members repos.IMembersRepo, clans repos.IClansRepo, guilds repos.IGuildsRepo, clanSettings repos.IClanSettingsRepo, kickpoints repos.IKickpointsRepo, blacklist repos.IBlacklistRepo, nicknames repos.INicknamesRepo, auth middleware.AuthMiddleware) IGuildMemberHandler {
+	return &GuildMemberHandler{
+		players:      players,
+		members:      members,
+		clans:        clans,
+		guilds:       guilds,
+		clanSettings: clanSettings,
+		kickpoints:   kickpoints,
+		blacklist:    blacklist,
+		nicknames:    nicknames,
+		auth:         auth,
+	}
+}
+
+func (h *GuildMemberHandler) LeftMembers(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	clanTag := util.StringOptionByName(ClanTagOptionName, i.ApplicationCommandData().Options)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Du musst einen Clan angeben.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	players, err := h.players.LeftServerPlayersByClan(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendLeftServerPlayers(i, clanName, players)
+}
+
+// LeftMembersComponent handles the buttons of the notifications sent by OnGuildMemberRemove and OnGuildMemberAdd.
+func (h *GuildMemberHandler) LeftMembersComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, _, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	if len(values) != 2 {
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+		return
+	}
+
+	clanTag, discordID := values[0], values[1]
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	members, err := h.clanMembersByDiscordID(discordID, clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	names := make([]string, len(members))
+	for index, member := range members {
+		names[index] = member.Player.Name
+	}
+
+	switch action {
+	case removeMembershipsAction:
+		for _, member := range members {
+			if err = h.members.DeleteMember(member.PlayerTag, member.ClanTag); err != nil {
+				messages.SendUnknownErr(i)
+				return
+			}
+		}
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Mitgliedschaften entfernt",
+			fmt.Sprintf("Die Accounts von %s (%s) wurden von %s aus dem Clan entfernt.", util.MentionUserID(discordID), strings.Join(names, ", "), i.Member.Mention()),
+			messages.ColorGreen,
+		))
+	case keepMembershipsAction:
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Mitgliedschaften beibehalten",
+			fmt.Sprintf("Die Accounts von %s (%s) bleiben Mitglied. Entschieden von %s.", util.MentionUserID(discordID), strings.Join(names, ", "), i.Member.Mention()),
+			messages.ColorAqua,
+		))
+	case restoreRolesAction:
+		warnings := h.restoreRoles(s, i.GuildID, discordID, clanTag, members)
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Rollen wiederhergestellt",
+			appendWarnings(fmt.Sprintf("Die Rollen von %s wurden von %s wiederhergestellt.", util.MentionUserID(discordID), i.Member.Mention()), warnings),
+			messages.ColorGreen,
+		))
+	case ignoreRejoinAction:
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Rollen nicht wiederhergestellt",
+			fmt.Sprintf("Die Rollen von %s wurden nicht wiederhergestellt. Entschieden von %s.", util.MentionUserID(discordID), i.Member.Mention()),
+			messages.ColorAqua,
+		))
+	default:
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+	}
+}
+
+func (h *GuildMemberHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Focused && opt.Name == ClanTagOptionName {
+			autocompleteClans(i, h.clans, opt.StringValue())
+		}
+	}
+}
+
+// OnGuildMemberRemove marks the players of the user and asks the leaders of their clans whether the memberships should be removed.
+func (h *GuildMemberHandler) OnGuildMemberRemove(_ *discordgo.Session, e *discordgo.GuildMemberRemove) {
+	if e.GuildID != env.DISCORD_GUILD_ID.Value() || e.User == nil {
+		return
+	}
+
+	players, err := h.players.PlayersByDiscordID(e.User.ID, "Members.Clan")
+	if err != nil || len(players) == 0 {
+		return
+	}
+
+	now := time.Now()
+	if err = h.players.UpdateLeftServerAt(e.User.ID, &now); err != nil {
+		slog.Error("Error while marking players as left.", slog.Any("err", err), slog.String("discordID", e.User.ID))
+	}
+
+	for clanTag, members := range membersByClan(players) {
+		h.notifyLeaders(clanTag, messages.LeftServerEmbed(e.User, memberClanName(members[0]), members), components.ButtonRow(
+			discordgo.Button{
+				Label:    "Mitgliedschaften entfernen",
+				Style:    discordgo.DangerButton,
+				CustomID: util.BuildComponentID(leftMembersCommandName, "", removeMembershipsAction, clanTag, e.User.ID),
+			},
+			discordgo.Button{
+				Label:    "Behalten",
+				Style:    discordgo.SecondaryButton,
+				CustomID: util.BuildComponentID(leftMembersCommandName, "", keepMembershipsAction, clanTag, e.User.ID),
+			},
+		))
+	}
+}
+
+// OnGuildMemberAdd removes the mark from the players of a rejoining user and asks the leaders of their clans whether the roles should be restored.
+func (h *GuildMemberHandler) OnGuildMemberAdd(_ *discordgo.Session, e *discordgo.GuildMemberAdd) {
+	if e.GuildID != env.DISCORD_GUILD_ID.Value() || e.User == nil {
+		return
+	}
+
+	players, err := h.players.PlayersByDiscordID(e.User.ID, "Members.Clan")
+	if err != nil || len(players) == 0 {
+		return
+	}
+
+	if err = h.players.UpdateLeftServerAt(e.User.ID, nil); err != nil {
+		slog.Error("Error while unmarking players.", slog.Any("err", err), slog.String("discordID", e.User.ID))
+	}
+
+	for clanTag, members := range membersByClan(players) {
+		embed := messages.RejoinedServerEmbed(e.User, memberClanName(members[0]), members)
+		for _, member := range members {
+			if history := rejoinHistory(h.members, h.kickpoints, h.blacklist, member.PlayerTag); !history.Empty() {
+				embed.Description = appendWarnings(embed.Description, []string{messages.RejoinWarning(member.Player.Name, history)})
+				embed.Color = messages.ColorYellow
+			}
+		}
+
+		h.notifyLeaders(clanTag, embed, components.ButtonRow(
+			discordgo.Button{
+				Label:    "Rollen wiederherstellen",
+				Style:    discordgo.SuccessButton,
+				CustomID: util.BuildComponentID(leftMembersCommandName, "", restoreRolesAction, clanTag, e.User.ID),
+			},
+			discordgo.Button{
+				Label:    "Ignorieren",
+				Style:    discordgo.SecondaryButton,
+				CustomID: util.BuildComponentID(leftMembersCommandName, "", ignoreRejoinAction, clanTag, e.User.ID),
+			},
+		))
+	}
+}
+
+func (h *GuildMemberHandler) notifyLeaders(clanTag string, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) {
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		slog.Error("Error while getting clan settings.", slog.Any("err", err), slog.String("clanTag", clanTag))
+		return
+	}
+
+	channelID := settings.ChannelID(models.ClanChannelLeader)
+	if channelID == "" {
+		slog.Warn("No leader channel set, notification was not sent.", slog.String("clanTag", clanTag))
+		return
+	}
+
+	messages.SendChannelComponents(channelID, embed, components)
+}
+
+func (h *GuildMemberHandler) restoreRoles(s *discordgo.Session, guildID, discordID, clanTag string, members models.ClanMembers) []string {
+	var warnings []string
+	if err := s.GuildMemberRoleAdd(guildID, discordID, env.DISCORD_VERIFIED_ROLE_ID.Value()); err != nil {
+		warnings = append(warnings, "Die Verified-Rolle konnte nicht zugewiesen werden.")
+	}
+
+	if len(members) > 0 {
+		guild, err := h.guilds.GuildByClanTag(guildID, clanTag)
+		if err == nil {
+			err = s.GuildMemberRoleAdd(guildID, discordID, guild.MemberRoleID)
+		}
+		if err != nil {
+			warnings = append(warnings, "Die Mitglieder-Rolle konnte nicht zugewiesen werden.")
+		}
+
+		if err = s.GuildMemberNickname(guildID, discordID, h.restoredNickname(discordID, members)); err != nil {
+			warnings = append(warnings, "Der Nickname konnte nicht geändert werden.")
+		}
+	}
+
+	return warnings
+}
+
+// restoredNickname returns the nickname the user had before leaving, built from the current name of the saved account.
+// Without a saved nickname the name of the first membership is used.
+func (h *GuildMemberHandler) restoredNickname(discordID string, members models.ClanMembers) string {
+	nickname, err := h.nicknames.NicknameByDiscordID(discordID)
+	if err != nil || nickname.PlayerTag == "" {
+		return members[0].Player.Name
+	}
+
+	name, err := h.players.NameByTag(nickname.PlayerTag)
+	if err != nil {
+		return members[0].Player.Name
+	}
+
+	nickname.AppliedNick = nickname.Build(name)
+	if err = h.nicknames.SaveNickname(nickname); err != nil {
+		slog.Error("Error while saving nickname.", slog.Any("err", err), slog.String("discordID", discordID))
+	}
+	return nickname.AppliedNick
+}
+
+// clanMembersByDiscordID returns the memberships in the clan of all players linked to the Discord user.
+func (h *GuildMemberHandler) clanMembersByDiscordID(discordID, clanTag string) (models.ClanMembers, error) {
+	players, err := h.players.PlayersByDiscordID(discordID, "Members")
+	if err != nil {
+		return nil, err
+	}
+
+	return membersByClan(players)[clanTag], nil
+}
+
+// membersByClan groups the memberships of the players by clan tag. The players have to be loaded with their members.
+func membersByClan(players models.Players) map[string]models.ClanMembers {
+	byClan := make(map[string]models.ClanMembers)
+	for _, player := range players {
+		for _, member := range player.Members {
+			member.Player = player
+			byClan[member.ClanTag] = append(byClan[member.ClanTag], member)
+		}
+	}
+	return byClan
+}
@@ -27,6 +27,7 @@ type IKickpointHandler interface {
 	KickpointInfo(s *discordgo.Session, i *discordgo.InteractionCreate)
 	ClanConfigModal(s *discordgo.Session, i *discordgo.InteractionCreate)
 	ClanConfigModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate)
+	ClanChannel(s *discordgo.Session, i *discordgo.InteractionCreate)
 	KickpointHelp(s *discordgo.Session, i *discordgo.InteractionCreate)
 	CreateKickpointModal(s *discordgo.Session, i *discordgo.InteractionCreate)
 	CreateKickpointModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate)
@@ -48,10 +49,11 @@ type KickpointHandler struct {
 	members      repos.IMembersRepo
 	clanSettings repos.IClanSettingsRepo
 	memberStates repos.IMemberStatesRepo
+	notes        repos.INotesRepo
 	auth         middleware.AuthMiddleware
 }
 
-func NewKickpointHandler(kickpoints repos.IKickpointsRepo, reasons repos.IKickpointReasonsRepo, clans repos.IClansRepo, players repos.IPlayersRepo, members repos.IMembersRepo, clanSettings repos.IClanSettingsRepo, memberStates repos.IMemberStatesRepo, auth middleware.AuthMiddleware) IKickpointHandler {
+func NewKickpointHandler(kickpoints repos.IKickpointsRepo, reasons repos.IKickpointReasonsRepo, clans repos.IClansRepo, players repos.IPlayersRepo, members repos.IMembersRepo, clanSettings repos.IClanSettingsRepo, memberStates repos.IMemberStatesRepo, notes repos.INotesRepo, auth middleware.AuthMiddleware) IKickpointHandler {
 	return &KickpointHandler{
 		kickpoints:   kickpoints,
 		reasons:      reasons,
@@ -60,6 +62,7 @@ func NewKickpointHandler(kickpoints repos.IKickpointsRepo, reasons repos.IKickpo
 		members:      members,
 		clanSettings: clanSettings,
 		memberStates: memberStates,
+		notes:        notes,
 		auth:         auth,
 	}
 }
@@ -152,6 +155,7 @@ func (h *KickpointHandler) MemberKickpoints(_ *discordgo.Session, i *discordgo.I
 			)
 
 			messages.SendEmbedResponse(i, embed)
+			h.sendNotesFollowup(i, playerTag)
 			return
 		}
 		messages.SendUnknownErr(i)
@@ -159,6 +163,15 @@ func (h *KickpointHandler) MemberKickpoints(_ *discordgo.Session, i *discordgo.I
 	}
 
 	messages.SendMemberKickpoints(i, kickpoints, kickpointSum, maxKickpoints, clanName)
+	h.sendNotesFollowup(i, playerTag)
+}
+
+func (h *KickpointHandler) sendNotesFollowup(i *discordgo.InteractionCreate, playerTag string) {
+	playerName, err := h.players.NameByTag(playerTag)
+	if err != nil {
+		return
+	}
+	sendNotesFollowup(i, &h.auth, h.notes, playerTag, playerName)
 }
 
 func (h *KickpointHandler) KickpointInfo(_ *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -231,19 +244,22 @@ func (h *KickpointHandler) ClanConfigModalSubmit(_ *discordgo.Session, i *discor
 		return
 	}
 
-	settings := &models.ClanSettings{
-		ClanTag:                   clanTag,
-		MaxKickpoints:             util.ParseIntModalInput(data.Components[0]),
-		MinSeasonWins:             util.ParseIntModalInput(data.Components[1]),
-		KickpointsExpireAfterDays: util.ParseIntModalInput(data.Components[2]),
+	// load the current settings, so that settings which are not part of the modal are kept
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
 	}
+	settings.MaxKickpoints = util.ParseIntModalInput(data.Components[0])
+	settings.MinSeasonWins = util.ParseIntModalInput(data.Components[1])
+	settings.KickpointsExpireAfterDays = util.ParseIntModalInput(data.Components[2])
 
 	if msg, ok := validation.ValidateClanSettings(settings); !ok {
 		messages.SendInvalidInputErr(i, msg)
 		return
 	}
 
-	if err := h.clanSettings.UpdateClanSettings(settings); err != nil {
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
 		messages.SendUnknownErr(i)
 		return
 	}
@@ -255,6 +271,50 @@ func (h *KickpointHandler) ClanConfigModalSubmit(_ *discordgo.Session, i *discor
 	))
 }
 
+func (h *KickpointHandler) ClanChannel(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	channelType := models.ClanChannel(util.StringOptionByName(ChannelTypeOptionName, opts))
+	channel, err := util.ChannelOptionByName(ChannelOptionName, opts)
+	if clanTag == "" || channelType == "" || err != nil {
+		messages.SendInvalidInputErr(i, "Du musst einen Clan, eine Art und einen Channel angeben.")
+		return
+	}
+
+	if err = h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	if !settings.SetChannelID(channelType, channel.ID) {
+		messages.SendInvalidInputErr(i, fmt.Sprintf("Die Art %s ist ungültig.", channelType.String()))
+		return
+	}
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Channel gesetzt",
+		fmt.Sprintf("%s von %s werden nun in <#%s> gesendet.", channelType.Format(), clanName, channel.ID),
+		messages.ColorGreen,
+	))
+}
+
 func (h *KickpointHandler) KickpointHelp(_ *discordgo.Session, i *discordgo.InteractionCreate) {
 	messages.SendKickpointHelp(i)
 }
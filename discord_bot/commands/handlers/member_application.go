@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/components"
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	applyCommandName = "apply" // component ids of the application cards are routed to this command
+
+	acceptApplicationAction  = "accept"
+	declineApplicationAction = "decline"
+)
+
+func (h *MemberHandler) Apply(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	playerTag := util.StringOptionByName(MyPlayerTagOptionName, opts)
+	if clanTag == "" || playerTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und deinen Account an.")
+		return
+	}
+	if !strings.HasPrefix(playerTag, "#") {
+		playerTag = "#" + playerTag
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	if _, err = h.players.PlayerByTagAndDiscordID(playerTag, i.Member.User.ID); err != nil {
+		messages.SendErr(i, fmt.Sprintf("Der Account %s ist nicht mit deinem Discord Account verknüpft. Bitte verifiziere dich zuerst mit `/verify`.", playerTag))
+		return
+	}
+
+	if _, err = h.members.MemberByID(playerTag, clanTag); err == nil {
+		messages.SendErr(i, fmt.Sprintf("Der Account %s ist bereits Mitglied von %s.", playerTag, clanName))
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	if settings.ChannelID(models.ClanChannelRecruitment) == "" {
+		messages.SendErr(i, fmt.Sprintf("%s nimmt momentan keine Bewerbungen über den Bot an.", clanName))
+		return
+	}
+
+	if err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID:   util.BuildCustomID(i.ApplicationCommandData().Name, i.Member.User.ID, clanTag+","+playerTag),
+			Title:      "Bewerbung",
+			Components: components.ApplicationModalComponents(),
+		},
+	}); err != nil {
+		slog.Error("Error while responding to Apply.", slog.Any("err", err))
+	}
+}
+
+func (h *MemberHandler) ApplyModalSubmit(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ModalSubmitData()
+	_, userID, otherID := util.ParseCustomID(data.CustomID)
+	clanTag, playerTag, ok := strings.Cut(otherID, ",")
+	if !ok || userID != i.Member.User.ID || len(data.Components) != len(components.ApplicationQuestions) {
+		messages.SendInvalidInputErr(i, "Die Bewerbung ist ungültig. Bitte versuche es erneut.")
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	if _, err = h.players.PlayerByTagAndDiscordID(playerTag, i.Member.User.ID); err != nil {
+		messages.SendErr(i, fmt.Sprintf("Der Account %s ist nicht mit deinem Discord Account verknüpft.", playerTag))
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	channelID := settings.ChannelID(models.ClanChannelRecruitment)
+	if channelID == "" {
+		messages.SendErr(i, fmt.Sprintf("%s nimmt momentan keine Bewerbungen über den Bot an.", clanName))
+		return
+	}
+
+	clashPlayer, err := h.clashClient.GetPlayer(playerTag)
+	if err != nil {
+		messages.SendCocApiErr(i, err)
+		return
+	}
+
+	answers := make([]*types.ApplicationAnswer, len(data.Components))
+	for index, c := range data.Components {
+		answers[index] = &types.ApplicationAnswer{
+			Question: components.ApplicationQuestions[index],
+			Answer:   util.ParseStringModalInput(c),
+		}
+	}
+
+	requirements := util.CheckClanRequirements(settings, clashPlayer)
+	embed := messages.ApplicationEmbed(i.Member.User, clanName, clashPlayer, requirements, answers)
+	// members of another family clan are not rejoining
+	entry := blacklistEntry(h.blacklist, playerTag)
+	if !isFamilyMember(h.members, playerTag) {
+		history := rejoinHistory(h.members, h.kickpoints, h.blacklist, playerTag)
+		if entry != nil {
+			// the active entry gets its own warning below
+			history.BlacklistEntry = nil
+		}
+		if !history.Empty() {
+			embed.Description = appendWarnings(embed.Description, []string{messages.RejoinWarning(clashPlayer.Name, history)})
+			embed.Color = messages.ColorYellow
+		}
+	}
+	if entry != nil {
+		embed.Description = appendWarnings(embed.Description, []string{messages.BlacklistWarning(entry)})
+		embed.Color = messages.ColorRed
+	}
+
+	if err = messages.SendChannelComponents(
+		channelID,
+		embed,
+		components.ButtonRow(
+			discordgo.Button{
+				Label:    "Annehmen",
+				Style:    discordgo.SuccessButton,
+				CustomID: util.BuildComponentID(applyCommandName, "", acceptApplicationAction, clanTag, playerTag, i.Member.User.ID),
+			},
+			discordgo.Button{
+				Label:    "Ablehnen",
+				Style:    discordgo.DangerButton,
+				CustomID: util.BuildComponentID(applyCommandName, "", declineApplicationAction, clanTag, playerTag, i.Member.User.ID),
+			},
+		),
+	); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	desc := fmt.Sprintf("Deine Bewerbung mit %s wurde an die Leitung von %s gesendet.", clashPlayer.Name, clanName)
+	for _, requirement := range requirements {
+		if !requirement.Met {
+			desc += "\n\nDein Account erfüllt nicht alle Anforderungen des Clans. Die Leitung entscheidet trotzdem über deine Bewerbung."
+			break
+		}
+	}
+
+	messages.SendEphemeralEmbedResponse(i, messages.NewEmbed(
+		"Bewerbung gesendet",
+		desc,
+		messages.ColorGreen,
+	))
+}
+
+// ApplyComponent handles the buttons of the application cards sent by ApplyModalSubmit.
+func (h *MemberHandler) ApplyComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, _, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	if len(values) != 3 || len(i.Message.Embeds) == 0 {
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+		return
+	}
+
+	clanTag, playerTag, applicantID := values[0], values[1], values[2]
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	switch action {
+	case acceptApplicationAction:
+		player, err := h.players.PlayerByTagAndDiscordID(playerTag, applicantID)
+		if err != nil {
+			messages.SendErr(i, fmt.Sprintf("Der Account %s ist nicht mehr mit %s verknüpft.", playerTag, util.MentionUserID(applicantID)))
+			return
+		}
+
+		if entry := blacklistEntry(h.blacklist, player.CocTag); entry != nil {
+			messages.SendEphemeralEmbedResponse(i, messages.BlacklistedEmbed(entry))
+			return
+		}
+
+		desc, err := h.addMember(s, i.GuildID, i.Member.User.ID, clanTag, player, models.RoleMember)
+		if err != nil {
+			messages.SendErr(i, "Beim Speichern des Mitglieds ist ein Fehler aufgetreten. Dies kann daran liegen, dass das Mitglied bereits existiert.")
+			return
+		}
+
+		messages.UpdateComponentMessage(i, messages.ApplicationDecisionEmbed(
+			i.Message.Embeds[0],
+			fmt.Sprintf("Angenommen von %s. %s", i.Member.Mention(), desc),
+			messages.ColorGreen,
+		))
+		sendNotesFollowup(i, &h.auth, h.notes, player.CocTag, player.Name)
+		notifyApplicant(applicantID, messages.NewEmbed(
+			"Bewerbung angenommen",
+			fmt.Sprintf("Deine Bewerbung mit %s wurde angenommen. Willkommen im Clan!", player.Name),
+			messages.ColorGreen,
+		))
+	case declineApplicationAction:
+		messages.UpdateComponentMessage(i, messages.ApplicationDecisionEmbed(
+			i.Message.Embeds[0],
+			fmt.Sprintf("Abgelehnt von %s.", i.Member.Mention()),
+			messages.ColorRed,
+		))
+		notifyApplicant(applicantID, messages.NewEmbed(
+			"Bewerbung abgelehnt",
+			fmt.Sprintf("Deine Bewerbung mit %s wurde leider abgelehnt.", playerTag),
+			messages.ColorRed,
+		))
+	default:
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+	}
+}
+
+func (h *MemberHandler) ClanRequirements(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	// only the given requirements are changed, 0 removes a requirement
+	if townHall := util.IntOptionByName(TownHallOptionName, opts); townHall != nil {
+		settings.MinTownHallLevel = *townHall
+	}
+	if heroLevels := util.IntOptionByName(HeroLevelsOptionName, opts); heroLevels != nil {
+		settings.MinHeroLevels = *heroLevels
+	}
+	if warStars := util.IntOptionByName(WarStarsOptionName, opts); warStars != nil {
+		settings.MinWarStars = *warStars
+	}
+	if league := util.IntOptionByName(LeagueOptionName, opts); league != nil {
+		settings.MinLeagueID = *league
+	}
+
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.ClanRequirementsEmbed(clanName, settings))
+}
+
+func notifyApplicant(discordID string, embed *discordgo.MessageEmbed) {
+	channel, err := util.Session.UserChannelCreate(discordID)
+	if err == nil {
+		_, err = util.Session.ChannelMessageSendEmbed(channel.ID, embed)
+	}
+	if err != nil {
+		slog.Warn("Could not notify applicant.", slog.Any("err", err), slog.String("discordID", discordID))
+	}
+}
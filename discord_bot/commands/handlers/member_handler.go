@@ -9,6 +9,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"gorm.io/gorm"
 
+	"bot/commands/components"
 	"bot/commands/messages"
 	"bot/commands/middleware"
 	"bot/commands/repos"
@@ -23,29 +24,49 @@ type IMemberHandler interface {
 	ListMembers(s *discordgo.Session, i *discordgo.InteractionCreate)
 	ClanMemberStatus(s *discordgo.Session, i *discordgo.InteractionCreate)
 	AddMember(s *discordgo.Session, i *discordgo.InteractionCreate)
+	AddMemberComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 	RemoveMember(s *discordgo.Session, i *discordgo.InteractionCreate)
 	EditMember(s *discordgo.Session, i *discordgo.InteractionCreate)
 	TransferMember(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Apply(s *discordgo.Session, i *discordgo.InteractionCreate)
+	ApplyModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate)
+	ApplyComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
+	ClanRequirements(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Promotions(s *discordgo.Session, i *discordgo.InteractionCreate)
+	PromotionsComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
+	PromotionSettings(s *discordgo.Session, i *discordgo.InteractionCreate)
 	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
 }
 
 type MemberHandler struct {
-	members     repos.IMembersRepo
-	clans       repos.IClansRepo
-	players     repos.IPlayersRepo
-	guilds      repos.IGuildsRepo
-	auth        middleware.AuthMiddleware
-	clashClient *goclash.Client
+	members      repos.IMembersRepo
+	clans        repos.IClansRepo
+	players      repos.IPlayersRepo
+	guilds       repos.IGuildsRepo
+	clanSettings repos.IClanSettingsRepo
+	kickpoints   repos.IKickpointsRepo
+	notes        repos.INotesRepo
+	blacklist    repos.IBlacklistRepo
+	absences     repos.IAbsencesRepo
+	wars         repos.IWarsRepo
+	auth         middleware.AuthMiddleware
+	clashClient  *goclash.Client
 }
 
-func NewMemberHandler(members repos.IMembersRepo, clans repos.IClansRepo, players repos.IPlayersRepo, guilds repos.IGuildsRepo, auth middleware.AuthMiddleware, clashClient *goclash.Client) IMemberHandler {
+func NewMemberHandler(members repos.IMembersRepo, clans repos.IClansRepo, players repos.IPlayersRepo, guilds repos.IGuildsRepo, clanSettings repos.IClanSettingsRepo, kickpoints repos.IKickpointsRepo, notes repos.INotesRepo, blacklist repos.IBlacklistRepo, absences repos.IAbsencesRepo, wars repos.IWarsRepo, auth middleware.AuthMiddleware, clashClient *goclash.Client) IMemberHandler {
 	return &MemberHandler{
-		members:     members,
-		clans:       clans,
-		players:     players,
-		guilds:      guilds,
-		auth:        auth,
-		clashClient: clashClient,
+		members:      members,
+		clans:        clans,
+		players:      players,
+		guilds:       guilds,
+		clanSettings: clanSettings,
+		kickpoints:   kickpoints,
+		notes:        notes,
+		blacklist:    blacklist,
+		absences:     absences,
+		wars:         wars,
+		auth:         auth,
+		clashClient:  clashClient,
 	}
 }
 
@@ -63,7 +84,7 @@ func (h *MemberHandler) ListMembers(_ *discordgo.Session, i *discordgo.Interacti
 		return
 	}
 
-	messages.SendClanMembers(i, clan)
+	messages.SendClanMembers(i, clan, activeAbsences(h.absences, clan.ClanMembers.Tags()))
 }
 
 func (h *MemberHandler) ClanMemberStatus(_ *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -105,14 +126,7 @@ func (h *MemberHandler) AddMember(s *discordgo.Session, i *discordgo.Interaction
 		return
 	}
 
-	requiredAuthRole := types.AuthRoleAdmin
-	if role == models.RoleMember || role == models.RoleElder {
-		requiredAuthRole = types.AuthRoleCoLeader
-	} else if role == models.RoleCoLeader {
-		requiredAuthRole = types.AuthRoleLeader
-	}
-
-	if err := h.auth.AuthorizeInteraction(i, clanTag, requiredAuthRole); err != nil {
+	if err := h.auth.AuthorizeInteraction(i, clanTag, addMemberAuthRole(role)); err != nil {
 		return
 	}
 
@@ -126,12 +140,25 @@ func (h *MemberHandler) AddMember(s *discordgo.Session, i *discordgo.Interaction
 		return
 	}
 
-	if err = h.members.CreateMember(&models.ClanMember{
-		PlayerTag:        playerTag,
-		ClanTag:          clanTag,
-		ClanRole:         role,
-		AddedByDiscordID: i.Member.User.ID,
-	}); err != nil {
+	if entry := blacklistEntry(h.blacklist, player.CocTag); entry != nil {
+		messages.SendEmbedResponse(i, messages.BlacklistedEmbed(entry))
+		return
+	}
+
+	// former members have to be confirmed, so leaders see why they left. Members of another family clan are not rejoining.
+	if !isFamilyMember(h.members, player.CocTag) {
+		if history := rejoinHistory(h.members, h.kickpoints, h.blacklist, player.CocTag); !history.Empty() {
+			cmdName := i.ApplicationCommandData().Name
+			messages.SendComponentsResponse(i, messages.RejoinWarningEmbed(player.Name, history), components.ConfirmButtons(
+				util.BuildComponentID(cmdName, i.Member.User.ID, components.ConfirmAction, clanTag, player.CocTag, string(role)),
+				util.BuildComponentID(cmdName, i.Member.User.ID, components.CancelAction),
+			))
+			return
+		}
+	}
+
+	desc, err := h.addMember(s, i.GuildID, i.Member.User.ID, clanTag, player, role)
+	if err != nil {
 		messages.SendEmbedResponse(i, messages.NewEmbed(
 			"Es ist ein Fehler aufgetreten",
 			"Beim Speichern des Mitglieds ist ein Fehler aufgetreten. Dies kann daran liegen, dass das Mitglied bereits existiert oder ungültige Daten angegeben wurden.",
@@ -140,9 +167,94 @@ func (h *MemberHandler) AddMember(s *discordgo.Session, i *discordgo.Interaction
 		return
 	}
 
-	guild, roleErr := h.guilds.GuildByClanTag(i.GuildID, clanTag)
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Mitglied hinzugefügt",
+		desc,
+		messages.ColorGreen,
+	))
+	sendNotesFollowup(i, &h.auth, h.notes, player.CocTag, player.Name)
+}
+
+// AddMemberComponent handles the confirmation sent by AddMember for former members.
+func (h *MemberHandler) AddMemberComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, userID, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	if !authorizeComponentUser(i, userID) {
+		return
+	}
+
+	if action != components.ConfirmAction || len(values) != 3 {
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Abgebrochen",
+			"Das Mitglied wurde nicht hinzugefügt.",
+			messages.ColorRed,
+		))
+		return
+	}
+
+	clanTag, playerTag, role := values[0], values[1], models.ClanRole(values[2])
+	if err := h.auth.AuthorizeInteraction(i, clanTag, addMemberAuthRole(role)); err != nil {
+		return
+	}
+
+	player, err := h.players.PlayerByTag(playerTag)
+	if err != nil || player.DiscordID == "" {
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Mitglied nicht verifiziert",
+			"Das Mitglied muss sich zuerst verifizieren, bevor es zu einem Clan hinzugefügt werden kann.",
+			messages.ColorRed,
+		))
+		return
+	}
+
+	if entry := blacklistEntry(h.blacklist, player.CocTag); entry != nil {
+		messages.UpdateComponentMessage(i, messages.BlacklistedEmbed(entry))
+		return
+	}
+
+	desc, err := h.addMember(s, i.GuildID, i.Member.User.ID, clanTag, player, role)
+	if err != nil {
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Es ist ein Fehler aufgetreten",
+			"Beim Speichern des Mitglieds ist ein Fehler aufgetreten. Dies kann daran liegen, dass das Mitglied bereits existiert oder ungültige Daten angegeben wurden.",
+			messages.ColorRed,
+		))
+		return
+	}
+
+	messages.UpdateComponentMessage(i, messages.NewEmbed(
+		"Mitglied hinzugefügt",
+		fmt.Sprintf("%s\n\nDie Warnung zur Vorgeschichte von %s wurde von %s bestätigt.", desc, player.Name, i.Member.Mention()),
+		messages.ColorGreen,
+	))
+	sendNotesFollowup(i, &h.auth, h.notes, player.CocTag, player.Name)
+}
+
+// addMemberAuthRole returns the role required to add a member with the given clan role.
+func addMemberAuthRole(role models.ClanRole) types.AuthRole {
+	switch role {
+	case models.RoleMember, models.RoleElder:
+		return types.AuthRoleCoLeader
+	case models.RoleCoLeader:
+		return types.AuthRoleLeader
+	default:
+		return types.AuthRoleAdmin
+	}
+}
+
+// addMember adds the verified player to the clan and grants the member role. Returns the description of the response, including warnings.
+func (h *MemberHandler) addMember(s *discordgo.Session, guildID, addedByDiscordID, clanTag string, player *models.Player, role models.ClanRole) (string, error) {
+	if err := h.members.CreateMember(&models.ClanMember{
+		PlayerTag:        player.CocTag,
+		ClanTag:          clanTag,
+		ClanRole:         role,
+		AddedByDiscordID: addedByDiscordID,
+	}); err != nil {
+		return "", err
+	}
+
+	guild, roleErr := h.guilds.GuildByClanTag(guildID, clanTag)
 	if roleErr == nil {
-		roleErr = s.GuildMemberRoleAdd(i.GuildID, player.DiscordID, guild.MemberRoleID)
+		roleErr = s.GuildMemberRoleAdd(guildID, player.DiscordID, guild.MemberRoleID)
 	}
 
 	desc := fmt.Sprintf("Das Mitglied wurde erfolgreich als %s zum Clan hinzugefügt.", role.Format())
@@ -150,8 +262,8 @@ func (h *MemberHandler) AddMember(s *discordgo.Session, i *discordgo.Interaction
 		desc += "\n\n**ACHTUNG**: Dem Mitglied konnte die Mitglieder-Rolle nicht zugewiesen werden. Bitte weise ihm die Rolle manuell zu."
 	}
 
-	if slices.Contains(i.Member.Roles, env.DISCORD_EX_MEMBER_ROLE_ID.Value()) {
-		if err = s.GuildMemberRoleRemove(i.GuildID, player.DiscordID, env.DISCORD_EX_MEMBER_ROLE_ID.Value()); err != nil {
+	if guildMember, err := s.GuildMember(guildID, player.DiscordID); err == nil && slices.Contains(guildMember.Roles, env.DISCORD_EX_MEMBER_ROLE_ID.Value()) {
+		if err = s.GuildMemberRoleRemove(guildID, player.DiscordID, env.DISCORD_EX_MEMBER_ROLE_ID.Value()); err != nil {
 			desc += fmt.Sprintf(
 				"\n\n**ACHTUNG**: Dem Mitglied konnte %s nicht entfernt werden. Bitte entferne ihm die Rolle manuell.",
 				util.MentionRole(env.DISCORD_EX_MEMBER_ROLE_ID.Value()),
@@ -159,11 +271,7 @@ func (h *MemberHandler) AddMember(s *discordgo.Session, i *discordgo.Interaction
 		}
 	}
 
-	messages.SendEmbedResponse(i, messages.NewEmbed(
-		"Mitglied hinzugefügt",
-		desc,
-		messages.ColorGreen,
-	))
+	return desc, nil
 }
 
 func (h *MemberHandler) RemoveMember(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -233,9 +341,10 @@ func (h *MemberHandler) RemoveMember(s *discordgo.Session, i *discordgo.Interact
 		desc,
 		messages.ColorGreen,
 	))
+	sendNotesFollowup(i, &h.auth, h.notes, member.PlayerTag, member.Player.Name)
 }
 
-func (h *MemberHandler) EditMember(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+func (h *MemberHandler) EditMember(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	opts := i.ApplicationCommandData().Options
 	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
 	memberTag := util.StringOptionByName(MemberTagOptionName, opts)
@@ -277,16 +386,18 @@ func (h *MemberHandler) EditMember(_ *discordgo.Session, i *discordgo.Interactio
 		return
 	}
 
-	if err = h.members.UpdateMemberRole(member.PlayerTag, member.ClanTag, role); err != nil {
+	warnings, err := h.updateMemberRole(s, i.GuildID, member, role)
+	if err != nil {
 		messages.SendUnknownErr(i)
 		return
 	}
 
 	messages.SendEmbedResponse(i, messages.NewEmbed(
 		"Mitglied geändert",
-		fmt.Sprintf("Das Mitglied %s hat nun die Rolle %s.", member.Player.Name, role.Format()),
+		appendWarnings(fmt.Sprintf("Das Mitglied %s hat nun die Rolle %s.", member.Player.Name, role.Format()), warnings),
 		messages.ColorGreen,
 	))
+	sendNotesFollowup(i, &h.auth, h.notes, member.PlayerTag, member.Player.Name)
 }
 
 func (h *MemberHandler) TransferMember(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -359,12 +470,19 @@ func (h *MemberHandler) TransferMember(s *discordgo.Session, i *discordgo.Intera
 	fromClanName, _ := h.clans.ClanNameByTag(fromClanTag)
 	toClanName, _ := h.clans.ClanNameByTag(toClanTag)
 
+	desc := fmt.Sprintf("Das Mitglied %s wurde erfolgreich von %s zu %s übertragen und hat nun die Rolle %s.",
+		currentMember.Player.Name, fromClanName, toClanName, role.Format())
+	// members on the blacklist are already in the family, so the transfer is only warned about
+	if entry := blacklistEntry(h.blacklist, playerTag); entry != nil {
+		desc = appendWarnings(desc, []string{messages.BlacklistWarning(entry)})
+	}
+
 	messages.SendEmbedResponse(i, messages.NewEmbed(
 		"Mitglied übertragen",
-		fmt.Sprintf("Das Mitglied %s wurde erfolgreich von %s zu %s übertragen und hat nun die Rolle %s.",
-			currentMember.Player.Name, fromClanName, toClanName, role.Format()),
+		desc,
 		messages.ColorGreen,
 	))
+	sendNotesFollowup(i, &h.auth, h.notes, currentMember.PlayerTag, currentMember.Player.Name)
 }
 
 func (h *MemberHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -382,6 +500,8 @@ func (h *MemberHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.In
 			autocompleteMembers(i, h.players, opt.StringValue(), util.StringOptionByName(ClanTagOptionName, opts))
 		case PlayerTagOptionName:
 			autocompletePlayers(i, h.players, opt.StringValue())
+		case MyPlayerTagOptionName:
+			autocompleteMyPlayers(i, h.players, opt.StringValue())
 		case "from_clan", "to_clan":
 			autocompleteClans(i, h.clans, opt.StringValue())
 		}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/components"
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	promotionsCommandName = "promotions" // component ids of the promotion buttons are routed to this command
+
+	promoteAction = "promote"
+
+	maxPromotionButtons = 5 // buttons per action row
+
+	promotionWarCount = 10 // latest stored wars used for the war attack participation
+)
+
+func (h *MemberHandler) Promotions(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	role := models.ClanRole(util.StringOptionByName(RoleOptionName, opts))
+	fromRole, ok := promotionFromRole(role)
+	if clanTag == "" || !ok {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und die Rolle an, zu der befördert werden soll.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	// fetching the live data of all members may take longer than 3 seconds
+	if err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		slog.Error("Failed to send deferred response", slog.Any("err", err))
+		return
+	}
+
+	candidates, err := h.promotionCandidates(clanTag, fromRole)
+	if err != nil {
+		if err = messages.CreateAndEditEmbed(s, i, "Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed); err != nil {
+			slog.Error("Failed to edit message.", slog.Any("err", err))
+		}
+		return
+	}
+
+	var buttons []discordgo.Button
+	for _, candidate := range candidates {
+		if len(buttons) == maxPromotionButtons || !candidate.Suggested() {
+			break
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    fmt.Sprintf("%s befördern", candidate.Member.Player.Name),
+			Style:    discordgo.SuccessButton,
+			CustomID: util.BuildComponentID(promotionsCommandName, "", promoteAction, clanTag, candidate.Member.PlayerTag, role.String()),
+		})
+	}
+
+	edit := &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{messages.PromotionsEmbed(clanName, role, candidates)},
+	}
+	if len(buttons) > 0 {
+		rows := components.ButtonRow(buttons...)
+		edit.Components = &rows
+	}
+
+	if _, err = s.InteractionResponseEdit(i.Interaction, edit); err != nil {
+		slog.Error("Failed to edit message.", slog.Any("err", err))
+	}
+}
+
+// PromotionsComponent handles the promotion buttons sent by Promotions.
+func (h *MemberHandler) PromotionsComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, _, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	if action != promoteAction || len(values) != 3 {
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+		return
+	}
+
+	clanTag, playerTag, role := values[0], values[1], models.ClanRole(values[2])
+	fromRole, ok := promotionFromRole(role)
+	if !ok {
+		messages.SendInvalidInputErr(i, "Diese Aktion ist ungültig.")
+		return
+	}
+
+	requiredAuthRole := types.AuthRoleCoLeader
+	if role == models.RoleCoLeader {
+		requiredAuthRole = types.AuthRoleLeader
+	}
+	if err := h.auth.AuthorizeInteraction(i, clanTag, requiredAuthRole); err != nil {
+		return
+	}
+
+	member, err := h.members.MemberByID(playerTag, clanTag)
+	if err != nil {
+		messages.SendMemberNotFound(i, playerTag, clanTag)
+		return
+	}
+
+	if member.ClanRole != fromRole {
+		messages.SendEphemeralEmbedResponse(i, messages.NewEmbed(
+			"Mitglied nicht geändert",
+			fmt.Sprintf("Das Mitglied %s hat inzwischen die Rolle %s.", member.Player.Name, member.ClanRole.Format()),
+			messages.ColorRed,
+		))
+		return
+	}
+
+	warnings, err := h.updateMemberRole(s, i.GuildID, member, role)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Mitglied befördert",
+		appendWarnings(fmt.Sprintf("%s wurde von %s zum %s befördert.", member.Player.Name, i.Member.Mention(), role.Format()), warnings),
+		messages.ColorGreen,
+	))
+}
+
+func (h *MemberHandler) PromotionSettings(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	// only the given criteria are changed
+	if days := util.IntOptionByName(MinDaysOptionName, opts); days != nil {
+		settings.PromotionMinDays = *days
+	}
+	if donations := util.IntOptionByName(DonationsOptionName, opts); donations != nil {
+		settings.PromotionMinDonations = *donations
+	}
+	if attackRate := util.IntOptionByName(AttackRateOptionName, opts); attackRate != nil {
+		settings.PromotionMinAttackRate = *attackRate
+	}
+	if kickpoints := util.IntOptionByName(KickpointsOptionName, opts); kickpoints != nil {
+		settings.PromotionMaxKickpoints = *kickpoints
+	}
+
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.PromotionSettingsEmbed(clanName, settings))
+}
+
+// promotionCandidates returns all members of the clan with the given role, ranked by the promotion criteria of the clan.
+func (h *MemberHandler) promotionCandidates(clanTag string, role models.ClanRole) ([]*types.PromotionCandidate, error) {
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := h.members.MembersByClanTag(clanTag)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := h.members.CurrentMemberHistory(clanTag)
+	if err != nil {
+		return nil, err
+	}
+	joinedAt := make(map[string]*time.Time, len(history))
+	for _, entry := range history {
+		joinedAt[entry.PlayerTag] = entry.JoinedAt
+	}
+
+	warAttacks, err := h.warAttacks(clanTag)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*types.PromotionCandidate
+	for _, member := range members {
+		if member.ClanRole != role {
+			continue
+		}
+
+		kickpoints, err := h.kickpoints.KickpointSum(member.PlayerTag)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, &types.PromotionCandidate{
+			Member:     member,
+			JoinedAt:   joinedAt[member.PlayerTag],
+			Kickpoints: kickpoints,
+			WarAttacks: warAttacks[member.PlayerTag],
+		})
+	}
+
+	tags := make([]string, len(candidates))
+	for index, candidate := range candidates {
+		tags[index] = candidate.Member.PlayerTag
+	}
+	if len(tags) > 0 {
+		for index, clashPlayer := range h.clashClient.GetPlayers(tags...) {
+			candidates[index].ClashPlayer = clashPlayer
+		}
+	}
+
+	util.RankPromotionCandidates(settings, candidates)
+	return candidates, nil
+}
+
+// warAttacks returns the attack usage of the members over the latest finished wars of the clan, by player tag.
+func (h *MemberHandler) warAttacks(clanTag string) (map[string]*types.WarAttackUsage, error) {
+	wars, err := h.wars.FinishedWars(clanTag, promotionWarCount, "Participants", "Attacks")
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]*types.WarAttackUsage)
+	for _, stats := range util.WarPlayerStatistics(wars) {
+		usages[stats.PlayerTag] = &types.WarAttackUsage{
+			Used:      stats.Attacks,
+			Available: stats.Attacks + stats.MissedAttacks,
+		}
+	}
+	return usages, nil
+}
+
+// updateMemberRole changes the clan role of the member and syncs the Discord role of the clan role. Returns warnings for everything that could not be done on Discord.
+func (h *MemberHandler) updateMemberRole(s *discordgo.Session, guildID string, member *models.ClanMember, role models.ClanRole) ([]string, error) {
+	if err := h.members.UpdateMemberRole(member.PlayerTag, member.ClanTag, role); err != nil {
+		return nil, err
+	}
+
+	if member.Player == nil || member.Player.DiscordID == "" {
+		return nil, nil
+	}
+	discordID := member.Player.DiscordID
+
+	guild, err := h.guilds.GuildByClanTag(guildID, member.ClanTag)
+	if err != nil {
+		return []string{"Die Discord-Rollen konnten nicht aktualisiert werden. Bitte passe sie manuell an."}, nil
+	}
+
+	// the member role is managed when members are added or removed
+	var warnings []string
+	if roleID := guild.RoleIDByClanRole(role); roleID != "" && roleID != guild.MemberRoleID {
+		if err = s.GuildMemberRoleAdd(guildID, discordID, roleID); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s konnte nicht zugewiesen werden.", util.MentionRole(roleID)))
+		}
+	}
+
+	oldRoleID := guild.RoleIDByClanRole(member.ClanRole)
+	if oldRoleID == "" || oldRoleID == guild.MemberRoleID || oldRoleID == guild.RoleIDByClanRole(role) || h.otherAccountHasRole(member) {
+		return warnings, nil
+	}
+	if err = s.GuildMemberRoleRemove(guildID, discordID, oldRoleID); err != nil {
+		warnings = append(warnings, fmt.Sprintf("%s konnte nicht entfernt werden.", util.MentionRole(oldRoleID)))
+	}
+
+	return warnings, nil
+}
+
+// otherAccountHasRole reports whether another account of the member's Discord user has the member's current role in the same clan.
+func (h *MemberHandler) otherAccountHasRole(member *models.ClanMember) bool {
+	players, err := h.players.PlayersByDiscordID(member.Player.DiscordID, "Members")
+	if err != nil {
+		return false
+	}
+
+	for _, player := range players {
+		for _, other := range player.Members {
+			if other.PlayerTag != member.PlayerTag && other.ClanTag == member.ClanTag && other.ClanRole == member.ClanRole {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// promotionFromRole returns the role members need to be promoted to the given role.
+func promotionFromRole(role models.ClanRole) (models.ClanRole, bool) {
+	switch role {
+	case models.RoleElder:
+		return models.RoleMember, true
+	case models.RoleCoLeader:
+		return models.RoleElder, true
+	default:
+		return "", false
+	}
+}
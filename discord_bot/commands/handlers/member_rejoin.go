@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	"bot/commands/repos"
+	"bot/types"
+)
+
+// rejoinHistory collects the past removals, kickpoints and blacklist entry of the player. Errors are logged and leave the affected part empty.
+func rejoinHistory(members repos.IMembersRepo, kickpoints repos.IKickpointsRepo, blacklist repos.IBlacklistRepo, playerTag string) *types.RejoinHistory {
+	history := &types.RejoinHistory{}
+
+	memberHistory, err := members.MemberHistory(playerTag)
+	if err != nil {
+		slog.Error("Error while getting member history.", slog.Any("err", err), slog.String("playerTag", playerTag))
+	}
+	for _, entry := range memberHistory {
+		if entry.Removed() {
+			history.Removals = append(history.Removals, entry)
+		}
+	}
+
+	if history.KickpointSum, err = kickpoints.KickpointSum(playerTag); err != nil {
+		slog.Error("Error while getting kickpoint sum.", slog.Any("err", err), slog.String("playerTag", playerTag))
+	}
+	if history.KickpointSum > 0 {
+		if history.Kickpoints, err = kickpoints.KickpointHistory(playerTag); err != nil {
+			slog.Error("Error while getting kickpoint history.", slog.Any("err", err), slog.String("playerTag", playerTag))
+		}
+	}
+
+	entry, err := blacklist.BlacklistEntry(playerTag)
+	if err == nil {
+		history.BlacklistEntry = entry
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Error while checking blacklist.", slog.Any("err", err), slog.String("playerTag", playerTag))
+	}
+
+	return history
+}
+
+// isFamilyMember reports whether the player currently is a member of a family clan. Such players are not rejoining, e.g. when added to a second clan.
+func isFamilyMember(members repos.IMembersRepo, playerTag string) bool {
+	memberships, err := members.MembersByPlayerTag(playerTag)
+	if err != nil {
+		slog.Error("Error while getting memberships.", slog.Any("err", err), slog.String("playerTag", playerTag))
+		return false
+	}
+	return len(memberships) > 0
+}
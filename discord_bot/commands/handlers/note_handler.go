@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/messages"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+type INoteHandler interface {
+	AddNote(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Notes(s *discordgo.Session, i *discordgo.InteractionCreate)
+	RemoveNote(s *discordgo.Session, i *discordgo.InteractionCreate)
+	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
+}
+
+type NoteHandler struct {
+	notes   repos.INotesRepo
+	clans   repos.IClansRepo
+	players repos.IPlayersRepo
+	members repos.IMembersRepo
+	auth    middleware.AuthMiddleware
+}
+
+func NewNoteHandler(notes repos.INotesRepo, clans repos.IClansRepo, players repos.IPlayersRepo, members repos.IMembersRepo, auth middleware.AuthMiddleware) INoteHandler {
+	return &NoteHandler{
+		notes:   notes,
+		clans:   clans,
+		players: players,
+		members: members,
+		auth:    auth,
+	}
+}
+
+func (h *NoteHandler) AddNote(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	memberTag := util.StringOptionByName(MemberTagOptionName, opts)
+	visibility := models.NoteVisibility(util.StringOptionByName(VisibilityOptionName, opts))
+	content := util.StringOptionByName(NoteOptionName, opts)
+
+	if clanTag == "" || memberTag == "" || visibility == "" || content == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan, ein Mitglied, die Sichtbarkeit und eine Notiz an.")
+		return
+	}
+
+	if visibility != models.NoteVisibilityCoLeader && visibility != models.NoteVisibilityFamily {
+		messages.SendInvalidInputErr(i, fmt.Sprintf("Die Sichtbarkeit %s ist ungültig.", visibility.String()))
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	member, err := h.members.MemberByID(memberTag, clanTag)
+	if err != nil {
+		messages.SendMemberNotFound(i, memberTag, clanTag)
+		return
+	}
+
+	note := &models.PlayerNote{
+		PlayerTag:          member.PlayerTag,
+		ClanTag:            member.ClanTag,
+		Content:            content,
+		Visibility:         visibility,
+		CreatedByDiscordID: i.Member.User.ID,
+	}
+	if err = h.notes.CreateNote(note); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEphemeralEmbedResponse(i, messages.NewEmbed(
+		fmt.Sprintf("Notiz #%d erstellt", note.ID),
+		fmt.Sprintf("Die Notiz zu %s wurde gespeichert. Sichtbar für: %s.", member.Player.Name, visibility.Format()),
+		messages.ColorGreen,
+	))
+}
+
+func (h *NoteHandler) Notes(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	playerTag := util.StringOptionByName(PlayerTagOptionName, opts)
+	if playerTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Spieler an.")
+		return
+	}
+
+	clanTags, err := h.auth.ClanTagsWithRole(i, types.AuthRoleCoLeader)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	if len(clanTags) == 0 {
+		messages.SendErr(i, "Nur Vize-Anführer und Anführer können Notizen einsehen.")
+		return
+	}
+
+	player, err := h.players.PlayerByTag(playerTag)
+	if err != nil {
+		messages.SendErr(i, "Es wurde kein Spieler mit dem angegebenen Spieler-Tag gefunden.")
+		return
+	}
+
+	notes, err := visibleNotes(i, &h.auth, h.notes, player.CocTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEphemeralEmbedResponse(i, messages.NotesEmbed(player.Name, notes))
+}
+
+func (h *NoteHandler) RemoveNote(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	id := util.UintOptionByName(IDOptionName, opts)
+	if id == nil {
+		messages.SendInvalidInputErr(i, "Du musst eine gültige Notiz ID angeben.")
+		return
+	}
+
+	note, err := h.notes.NoteByID(*id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			messages.SendErr(i, fmt.Sprintf("Es wurde keine Notiz mit der ID %d gefunden.", *id))
+			return
+		}
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	// the author may always remove their own note
+	if note.CreatedByDiscordID != i.Member.User.ID {
+		if err = h.auth.AuthorizeInteraction(i, note.ClanTag, types.AuthRoleCoLeader); err != nil {
+			return
+		}
+	}
+
+	if err = h.notes.DeleteNote(note.ID); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEphemeralEmbedResponse(i, messages.NewEmbed(
+		fmt.Sprintf("Notiz #%d gelöscht", note.ID),
+		"Die Notiz wurde gelöscht.",
+		messages.ColorGreen,
+	))
+}
+
+func (h *NoteHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	for _, opt := range opts {
+		if !opt.Focused {
+			continue
+		}
+
+		switch opt.Name {
+		case ClanTagOptionName:
+			autocompleteClans(i, h.clans, opt.StringValue())
+		case MemberTagOptionName:
+			autocompleteMembers(i, h.players, opt.StringValue(), util.StringOptionByName(ClanTagOptionName, opts))
+		case PlayerTagOptionName:
+			autocompletePlayers(i, h.players, opt.StringValue())
+		}
+	}
+}
+
+// visibleNotes returns all notes about the player, which the user of the interaction is allowed to see.
+func visibleNotes(i *discordgo.InteractionCreate, auth *middleware.AuthMiddleware, notes repos.INotesRepo, playerTag string) (models.PlayerNotes, error) {
+	clanTags, err := auth.ClanTagsWithRole(i, types.AuthRoleCoLeader)
+	if err != nil || len(clanTags) == 0 {
+		return nil, err
+	}
+
+	allNotes, err := notes.NotesByPlayerTag(playerTag)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make(models.PlayerNotes, 0, len(allNotes))
+	for _, note := range allNotes {
+		if note.Visibility == models.NoteVisibilityFamily ||
+			note.CreatedByDiscordID == i.Member.User.ID ||
+			slices.Contains(clanTags, note.ClanTag) {
+			visible = append(visible, note)
+		}
+	}
+
+	return visible, nil
+}
+
+// sendNotesFollowup sends the notes about the player as ephemeral followup, if the user is allowed to see any.
+func sendNotesFollowup(i *discordgo.InteractionCreate, auth *middleware.AuthMiddleware, notes repos.INotesRepo, playerTag, playerName string) {
+	visible, err := visibleNotes(i, auth, notes, playerTag)
+	if err != nil || len(visible) == 0 {
+		return
+	}
+
+	messages.SendEphemeralFollowup(i, messages.NotesEmbed(playerName, visible))
+}
@@ -1,22 +1,54 @@
 package handlers
 
 const (
-	IDOptionName          = "id"
-	ClanTagOptionName     = "clan"
-	MemberTagOptionName   = "member"
-	PlayerTagOptionName   = "player"
-	MyPlayerTagOptionName = "my_player"
-	RoleOptionName        = "role"
-	AmountOptionName      = "amount"
-	LimitOptionName       = "limit"
-	StatisticOptionName   = "statistic"
-	ApiTokenOptionName    = "api_token"
-	MessageOptionName     = "message"
-	StartsAtOptionName    = "starts_at"
-	EndsAtOptionName      = "ends_at"
-	ReasonOptionName      = "reason"
-	AliasOptionName       = "alias"
-	MessageIDOptionName   = "message_id"
-	EmojiOptionName       = "emoji"
-	ChannelOptionName     = "channel"
+	IDOptionName             = "id"
+	ClanTagOptionName        = "clan"
+	MemberTagOptionName      = "member"
+	PlayerTagOptionName      = "player"
+	MyPlayerTagOptionName    = "my_player"
+	RoleOptionName           = "role"
+	AmountOptionName         = "amount"
+	LimitOptionName          = "limit"
+	StatisticOptionName      = "statistic"
+	ApiTokenOptionName       = "api_token"
+	MessageOptionName        = "message"
+	StartsAtOptionName       = "starts_at"
+	EndsAtOptionName         = "ends_at"
+	ReasonOptionName         = "reason"
+	AliasOptionName          = "alias"
+	MessageIDOptionName      = "message_id"
+	EmojiOptionName          = "emoji"
+	ChannelOptionName        = "channel"
+	NoteOptionName           = "note"
+	VisibilityOptionName     = "visibility"
+	UserOptionName           = "user"
+	ChannelTypeOptionName    = "type"
+	EnabledOptionName        = "enabled"
+	TownHallOptionName       = "town_hall"
+	HeroLevelsOptionName     = "hero_levels"
+	WarStarsOptionName       = "war_stars"
+	LeagueOptionName         = "league"
+	ExpiresAtOptionName      = "expires_at"
+	MinDaysOptionName        = "min_days"
+	DonationsOptionName      = "donations"
+	KickpointsOptionName     = "kickpoints"
+	AttackRateOptionName     = "attack_rate"
+	HoursOptionName          = "hours"
+	WarsOptionName           = "wars"
+	AutoPostOptionName       = "auto_post"
+	RangeSizeOptionName      = "range_size"
+	TargetOptionName         = "target"
+	ExpiryOptionName         = "expiry_minutes"
+	TownHallsBelowOptionName = "town_halls_below"
+	SeasonOptionName         = "season"
+	RosterSizeOptionName     = "roster_size"
+	BonusesOptionName        = "bonuses"
+	StarsOptionName          = "stars"
+	DestructionOptionName    = "destruction"
+	AttacksOptionName        = "attacks"
+	TownHallDiffOptionName   = "town_hall_diff"
+	TimesOptionName          = "times"
+	WeekendsOptionName       = "weekends"
+	MinPointsOptionName      = "min_points"
+	MinRatioOptionName       = "min_ratio"
 )
@@ -13,11 +13,14 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"gorm.io/gorm"
 
+	"bot/commands/components"
 	"bot/commands/messages"
+	"bot/commands/middleware"
 	"bot/commands/repos"
 	"bot/commands/util"
 	"bot/env"
 	"bot/store/postgres/models"
+	"bot/types"
 )
 
 type IPlayerHandler interface {
@@ -26,20 +29,47 @@ type IPlayerHandler interface {
 	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
 	SetNickname(s *discordgo.Session, i *discordgo.InteractionCreate)
 	CheckReactions(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Profile(s *discordgo.Session, i *discordgo.InteractionCreate)
+	UserProfile(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Unverify(s *discordgo.Session, i *discordgo.InteractionCreate)
+	UnverifyComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Relink(s *discordgo.Session, i *discordgo.InteractionCreate)
+	RelinkComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
+	NicknameSync(s *discordgo.Session, i *discordgo.InteractionCreate)
+	NicknameReport(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Scout(s *discordgo.Session, i *discordgo.InteractionCreate)
 }
 
 type PlayerHandler struct {
-	players     repos.IPlayersRepo
-	clashClient *goclash.Client
+	players      repos.IPlayersRepo
+	members      repos.IMembersRepo
+	guilds       repos.IGuildsRepo
+	kickpoints   repos.IKickpointsRepo
+	memberStates repos.IMemberStatesRepo
+	nicknames    repos.INicknamesRepo
+	blacklist    repos.IBlacklistRepo
+	auth         middleware.AuthMiddleware
+	clashClient  *goclash.Client
 }
 
 const cocVerificationStatusOK = "ok"
 
-func NewPlayerHandler(players repos.IPlayersRepo, clashClient *goclash.Client) IPlayerHandler {
-	return &PlayerHandler{
-		players:     players,
-		clashClient: clashClient,
+func NewPlayerHandler(players repos.IPlayersRepo, members repos.IMembersRepo, guilds repos.IGuildsRepo, kickpoints repos.IKickpointsRepo, memberStates repos.IMemberStatesRepo, nicknames repos.INicknamesRepo, blacklist repos.IBlacklistRepo, auth middleware.AuthMiddleware, clashClient *goclash.Client) IPlayerHandler {
+	h := &PlayerHandler{
+		players:      players,
+		members:      members,
+		guilds:       guilds,
+		kickpoints:   kickpoints,
+		memberStates: memberStates,
+		nicknames:    nicknames,
+		blacklist:    blacklist,
+		auth:         auth,
+		clashClient:  clashClient,
 	}
+
+	go h.syncNicknames()
+
+	return h
 }
 
 func (h *PlayerHandler) VerifyPlayer(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -75,6 +105,11 @@ func (h *PlayerHandler) VerifyPlayer(s *discordgo.Session, i *discordgo.Interact
 		return
 	}
 
+	if entry := blacklistEntry(h.blacklist, strings.ToUpper(playerTag)); entry != nil {
+		messages.SendErr(i, "Dieser Account kann nicht verifiziert werden. Bitte wende dich an die Leitung.")
+		return
+	}
+
 	player, err := h.clashClient.GetPlayer(playerTag)
 	if err != nil {
 		messages.SendCocApiErr(i, err)
@@ -90,6 +125,7 @@ func (h *PlayerHandler) VerifyPlayer(s *discordgo.Session, i *discordgo.Interact
 		return
 	}
 
+	var nickChanged bool
 	existingPlayers, err := h.players.PlayersByDiscordID(i.Member.User.ID)
 	if (err == nil || errors.Is(err, gorm.ErrRecordNotFound)) && len(existingPlayers) == 0 {
 		if _, err = s.GuildMemberEdit(i.GuildID, i.Member.User.ID, &discordgo.GuildMemberParams{
@@ -102,6 +138,7 @@ func (h *PlayerHandler) VerifyPlayer(s *discordgo.Session, i *discordgo.Interact
 			))
 			return
 		}
+		nickChanged = true
 	}
 
 	if err = h.players.CreateOrUpdatePlayer(&models.Player{
@@ -117,6 +154,10 @@ func (h *PlayerHandler) VerifyPlayer(s *discordgo.Session, i *discordgo.Interact
 		return
 	}
 
+	if nickChanged {
+		h.saveNickname(i.Member.User.ID, player.Tag, "", player.Name)
+	}
+
 	if err = s.GuildMemberRoleAdd(i.GuildID, i.Member.User.ID, env.DISCORD_VERIFIED_ROLE_ID.Value()); err != nil {
 		messages.SendEmbedResponse(i, messages.NewEmbed(
 			"Erfolgreich verifiziert",
@@ -191,16 +232,14 @@ func (h *PlayerHandler) SetNickname(s *discordgo.Session, i *discordgo.Interacti
 		return
 	}
 
-	nick := player.Name
-	if alias != "" {
-		nick += fmt.Sprintf(" | %s", alias)
-	}
+	nick := (&models.Nickname{Alias: alias}).Build(player.Name)
 	if _, err = s.GuildMemberEdit(i.GuildID, player.DiscordID, &discordgo.GuildMemberParams{
 		Nick: nick,
 	}); err != nil {
 		messages.SendErr(i, "Beim Ändern deines Nicknamen ist ein Fehler aufgetreten.")
 		return
 	}
+	h.saveNickname(player.DiscordID, player.CocTag, alias, nick)
 
 	messages.SendEmbedResponse(i, messages.NewEmbed(
 		"Erfolgreich geändert",
@@ -305,6 +344,258 @@ func (h *PlayerHandler) CheckReactions(s *discordgo.Session, i *discordgo.Intera
 	messages.SendChannelMessage(i.ChannelID, strings.Join(mentions, " "))
 }
 
+func (h *PlayerHandler) Profile(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	user := util.UserOptionByName(UserOptionName, i.ApplicationCommandData())
+	if user == nil {
+		user = i.Member.User
+	}
+
+	h.sendProfile(s, i, user)
+}
+
+// UserProfile is the handler for the user context menu command.
+func (h *PlayerHandler) UserProfile(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	user, ok := data.Resolved.Users[data.TargetID]
+	if !ok {
+		messages.SendInvalidInputErr(i, "Der ausgewählte Benutzer konnte nicht gefunden werden.")
+		return
+	}
+
+	h.sendProfile(s, i, user)
+}
+
+func (h *PlayerHandler) sendProfile(s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User) {
+	// fetching the live data of all accounts may take longer than 3 seconds
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		slog.Error("Failed to send deferred response", slog.Any("err", err))
+		return
+	}
+
+	players, err := h.players.PlayersByDiscordID(user.ID, "Members.Clan")
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		if err = messages.CreateAndEditEmbed(s, i, "Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed); err != nil {
+			slog.Error("Failed to edit message.", slog.Any("err", err))
+		}
+		return
+	}
+
+	clashPlayers := h.clashClient.GetPlayers(players.Tags()...)
+	profiles := make([]*types.PlayerProfile, len(players))
+	for index, player := range players {
+		activeKickpoints, err := h.kickpoints.ActiveMemberKickpointsSum(player.CocTag)
+		if err != nil {
+			slog.Error("Error while getting active kickpoints.", slog.Any("err", err))
+		}
+
+		var lockedClanTags []string
+		for _, member := range player.Members {
+			if locked, _ := h.memberStates.IsKickpointLocked(member.PlayerTag, member.ClanTag); locked {
+				lockedClanTags = append(lockedClanTags, member.ClanTag)
+			}
+		}
+
+		profiles[index] = &types.PlayerProfile{
+			Player:           player,
+			ClashPlayer:      clashPlayers[index],
+			ActiveKickpoints: activeKickpoints,
+			LockedClanTags:   lockedClanTags,
+		}
+	}
+
+	if _, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{messages.ProfileEmbed(user, profiles)},
+	}); err != nil {
+		slog.Error("Failed to edit message.", slog.Any("err", err))
+	}
+}
+
+func (h *PlayerHandler) Unverify(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	playerTag := util.StringOptionByName(MyPlayerTagOptionName, i.ApplicationCommandData().Options)
+	if playerTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Spieler-Tag an.")
+		return
+	}
+	if !strings.HasPrefix(playerTag, "#") {
+		playerTag = "#" + playerTag
+	}
+
+	player, err := h.players.PlayerByTagAndDiscordID(playerTag, i.Member.User.ID)
+	if err != nil {
+		messages.SendErr(i, fmt.Sprintf("Der Account %s ist nicht mit deinem Discord Account verknüpft.", playerTag))
+		return
+	}
+
+	members, err := h.members.MembersByPlayerTag(player.CocTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	desc := fmt.Sprintf("Möchtest du die Verknüpfung deines Accounts %s (%s) wirklich aufheben?", player.Name, player.CocTag)
+	if len(members) > 0 {
+		clanNames := make([]string, len(members))
+		for index, member := range members {
+			clanNames[index] = memberClanName(member)
+		}
+		desc += fmt.Sprintf("\n\n**ACHTUNG**: Der Account wird dadurch auch aus folgenden Clans entfernt: %s", strings.Join(clanNames, ", "))
+	}
+
+	cmdName := i.ApplicationCommandData().Name
+	messages.SendComponentsResponse(i, messages.NewEmbed(
+		"Verknüpfung aufheben",
+		desc,
+		messages.ColorYellow,
+	), components.ConfirmButtons(
+		util.BuildComponentID(cmdName, i.Member.User.ID, components.ConfirmAction, player.CocTag),
+		util.BuildComponentID(cmdName, i.Member.User.ID, components.CancelAction),
+	))
+}
+
+func (h *PlayerHandler) UnverifyComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, userID, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	if !authorizeComponentUser(i, userID) {
+		return
+	}
+
+	if action != components.ConfirmAction || len(values) != 1 {
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Abgebrochen",
+			"Die Verknüpfung wurde nicht aufgehoben.",
+			messages.ColorRed,
+		))
+		return
+	}
+
+	player, err := h.players.PlayerByTagAndDiscordID(values[0], userID)
+	if err != nil {
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Fehler",
+			fmt.Sprintf("Der Account %s ist nicht mehr mit deinem Discord Account verknüpft.", values[0]),
+			messages.ColorRed,
+		))
+		return
+	}
+
+	warnings, err := h.unlinkPlayer(s, i.GuildID, player, true)
+	if err != nil {
+		slog.Error("Error while unlinking player.", slog.Any("err", err))
+		messages.UpdateComponentMessage(i, messages.NewEmbed("Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+
+	messages.UpdateComponentMessage(i, messages.NewEmbed(
+		"Verknüpfung aufgehoben",
+		appendWarnings(fmt.Sprintf("Die Verknüpfung deines Accounts %s (%s) wurde aufgehoben.", player.Name, player.CocTag), warnings),
+		messages.ColorGreen,
+	))
+}
+
+func (h *PlayerHandler) Relink(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	playerTag := util.StringOptionByName(PlayerTagOptionName, data.Options)
+	user := util.UserOptionByName(UserOptionName, data)
+	if playerTag == "" || user == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Spieler und einen Benutzer an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeAdminInteraction(i); err != nil {
+		return
+	}
+
+	if user.Bot {
+		messages.SendInvalidInputErr(i, "Ein Account kann nicht mit einem Bot verknüpft werden.")
+		return
+	}
+
+	player, err := h.players.PlayerByTag(playerTag)
+	if err != nil {
+		messages.SendErr(i, "Es wurde kein Spieler mit dem angegebenen Spieler-Tag gefunden.")
+		return
+	}
+
+	if player.DiscordID == user.ID {
+		messages.SendErr(i, fmt.Sprintf("Der Account %s ist bereits mit %s verknüpft.", player.Name, user.Mention()))
+		return
+	}
+
+	currentOwner := "keinem Benutzer"
+	if player.DiscordID != "" {
+		currentOwner = util.MentionUserID(player.DiscordID)
+	}
+
+	cmdName := data.Name
+	messages.SendComponentsResponse(i, messages.NewEmbed(
+		"Account verschieben",
+		fmt.Sprintf(
+			"Soll der Account %s (%s) von %s zu %s verschoben werden?\nClan-Mitgliedschaften bleiben bestehen, die Rollen und der Nickname werden übertragen.",
+			player.Name,
+			player.CocTag,
+			currentOwner,
+			user.Mention(),
+		),
+		messages.ColorYellow,
+	), components.ConfirmButtons(
+		util.BuildComponentID(cmdName, i.Member.User.ID, components.ConfirmAction, player.CocTag, user.ID),
+		util.BuildComponentID(cmdName, i.Member.User.ID, components.CancelAction),
+	))
+}
+
+func (h *PlayerHandler) RelinkComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	_, userID, action, values := util.ParseComponentID(i.MessageComponentData().CustomID)
+	if !authorizeComponentUser(i, userID) {
+		return
+	}
+
+	if action != components.ConfirmAction || len(values) != 2 {
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Abgebrochen",
+			"Der Account wurde nicht verschoben.",
+			messages.ColorRed,
+		))
+		return
+	}
+
+	playerTag, discordID := values[0], values[1]
+	player, err := h.players.PlayerByTag(playerTag)
+	if err != nil {
+		messages.UpdateComponentMessage(i, messages.NewEmbed(
+			"Fehler",
+			"Es wurde kein Spieler mit dem angegebenen Spieler-Tag gefunden.",
+			messages.ColorRed,
+		))
+		return
+	}
+
+	var warnings []string
+	if player.DiscordID != "" && player.DiscordID != discordID {
+		if warnings, err = h.unlinkPlayer(s, i.GuildID, player, false); err != nil {
+			slog.Error("Error while unlinking player.", slog.Any("err", err))
+			messages.UpdateComponentMessage(i, messages.NewEmbed("Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed))
+			return
+		}
+	}
+
+	linkWarnings, err := h.linkPlayer(s, i.GuildID, player, discordID)
+	if err != nil {
+		slog.Error("Error while linking player.", slog.Any("err", err))
+		messages.UpdateComponentMessage(i, messages.NewEmbed("Unbekannter Fehler", "Es ist ein unbekannter Fehler aufgetreten.", messages.ColorRed))
+		return
+	}
+
+	messages.UpdateComponentMessage(i, messages.NewEmbed(
+		"Account verschoben",
+		appendWarnings(
+			fmt.Sprintf("Der Account %s (%s) ist nun mit %s verknüpft.", player.Name, player.CocTag, util.MentionUserID(discordID)),
+			append(warnings, linkWarnings...),
+		),
+		messages.ColorGreen,
+	))
+}
+
 func (h *PlayerHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
 	opts := i.ApplicationCommandData().Options
 	for _, opt := range opts {
@@ -316,17 +607,7 @@ func (h *PlayerHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.In
 		case PlayerTagOptionName:
 			autocompletePlayers(i, h.players, opt.StringValue())
 		case MyPlayerTagOptionName:
-			h.autocompleteMyPlayers(i, opt.StringValue())
+			autocompleteMyPlayers(i, h.players, opt.StringValue())
 		}
 	}
 }
-
-func (h *PlayerHandler) autocompleteMyPlayers(i *discordgo.InteractionCreate, query string) {
-	players, err := h.players.MyPlayers(i.Member.User.ID, query)
-	if err != nil {
-		messages.SendAutoCompletion(i, nil)
-		return
-	}
-
-	messages.SendAutoCompletion(i, players.Choices())
-}
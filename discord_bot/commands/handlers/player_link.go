@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/util"
+	"bot/env"
+	"bot/store/postgres/models"
+)
+
+// unlinkPlayer removes the link between the player and its Discord user, including the verified role, the member roles and the nickname.
+// If removeMemberships is true, the clan memberships of the player are deleted as well. Returns warnings for everything that could not be done on Discord.
+func (h *PlayerHandler) unlinkPlayer(s *discordgo.Session, guildID string, player *models.Player, removeMemberships bool) ([]string, error) {
+	discordID := player.DiscordID
+	members, err := h.members.MembersByPlayerTag(player.CocTag)
+	if err != nil {
+		return nil, err
+	}
+
+	otherPlayers, err := h.players.PlayersByDiscordID(discordID, "Members")
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	otherPlayers = slices.DeleteFunc(otherPlayers, func(p *models.Player) bool {
+		return p.CocTag == player.CocTag
+	})
+
+	if err = h.players.CreateOrUpdatePlayer(&models.Player{CocTag: player.CocTag, Name: player.Name}); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, member := range members {
+		if removeMemberships {
+			if err = h.members.DeleteMember(member.PlayerTag, member.ClanTag); err != nil {
+				return warnings, err
+			}
+		}
+
+		// the user keeps the member role if another account is in the same clan
+		if playersInClan(otherPlayers, member.ClanTag) {
+			continue
+		}
+
+		guild, err := h.guilds.GuildByClanTag(guildID, member.ClanTag)
+		if err == nil {
+			err = s.GuildMemberRoleRemove(guildID, discordID, guild.MemberRoleID)
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Die Mitglieder-Rolle von %s konnte nicht entfernt werden.", memberClanName(member)))
+		}
+	}
+
+	if removeMemberships && len(members) > 0 && !playersInClan(otherPlayers, "") {
+		if err = s.GuildMemberRoleAdd(guildID, discordID, env.DISCORD_EX_MEMBER_ROLE_ID.Value()); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s konnte nicht zugewiesen werden.", util.MentionRole(env.DISCORD_EX_MEMBER_ROLE_ID.Value())))
+		}
+	}
+
+	if len(otherPlayers) == 0 {
+		if err = s.GuildMemberRoleRemove(guildID, discordID, env.DISCORD_VERIFIED_ROLE_ID.Value()); err != nil {
+			warnings = append(warnings, "Die Verified-Rolle konnte nicht entfernt werden.")
+		}
+	}
+
+	// replace the player name in the nickname, but keep a possible alias
+	if guildMember, err := s.GuildMember(guildID, discordID); err == nil && strings.HasPrefix(guildMember.Nick, player.Name) {
+		var nick string
+		if len(otherPlayers) > 0 {
+			nick = otherPlayers[0].Name + strings.TrimPrefix(guildMember.Nick, player.Name)
+		}
+		if err = s.GuildMemberNickname(guildID, discordID, nick); err != nil {
+			warnings = append(warnings, "Der Nickname konnte nicht geändert werden.")
+		} else if nickname, err := h.nicknames.NicknameByDiscordID(discordID); err == nil && nick != "" && nickname.PlayerTag == player.CocTag {
+			h.saveNickname(discordID, otherPlayers[0].CocTag, nickname.Alias, nick)
+		}
+	}
+
+	return warnings, nil
+}
+
+// linkPlayer links the player with the Discord user and grants the verified role and the member roles of the player's clans.
+// Returns warnings for everything that could not be done on Discord.
+func (h *PlayerHandler) linkPlayer(s *discordgo.Session, guildID string, player *models.Player, discordID string) ([]string, error) {
+	existingPlayers, err := h.players.PlayersByDiscordID(discordID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	members, err := h.members.MembersByPlayerTag(player.CocTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.players.CreateOrUpdatePlayer(&models.Player{CocTag: player.CocTag, Name: player.Name, DiscordID: discordID}); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	if err = s.GuildMemberRoleAdd(guildID, discordID, env.DISCORD_VERIFIED_ROLE_ID.Value()); err != nil {
+		warnings = append(warnings, "Die Verified-Rolle konnte nicht zugewiesen werden.")
+	}
+
+	for _, member := range members {
+		guild, err := h.guilds.GuildByClanTag(guildID, member.ClanTag)
+		if err == nil {
+			err = s.GuildMemberRoleAdd(guildID, discordID, guild.MemberRoleID)
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Die Mitglieder-Rolle von %s konnte nicht zugewiesen werden.", memberClanName(member)))
+		}
+	}
+
+	if len(members) > 0 {
+		if err = s.GuildMemberRoleRemove(guildID, discordID, env.DISCORD_EX_MEMBER_ROLE_ID.Value()); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s konnte nicht entfernt werden.", util.MentionRole(env.DISCORD_EX_MEMBER_ROLE_ID.Value())))
+		}
+	}
+
+	if len(existingPlayers) == 0 {
+		if err = s.GuildMemberNickname(guildID, discordID, player.Name); err != nil {
+			warnings = append(warnings, "Der Nickname konnte nicht geändert werden.")
+		} else {
+			h.saveNickname(discordID, player.CocTag, "", player.Name)
+		}
+	}
+
+	return warnings, nil
+}
+
+// playersInClan reports whether any of the players is a member of the clan. If clanTag is empty, any clan matches.
+func playersInClan(players models.Players, clanTag string) bool {
+	for _, player := range players {
+		for _, member := range player.Members {
+			if clanTag == "" || member.ClanTag == clanTag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func memberClanName(member *models.ClanMember) string {
+	if member.Clan != nil {
+		return member.Clan.Name
+	}
+	return member.ClanTag
+}
+
+func appendWarnings(desc string, warnings []string) string {
+	for _, warning := range warnings {
+		desc += fmt.Sprintf("\n\n**ACHTUNG**: %s", warning)
+	}
+	return desc
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/env"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const nicknameSyncInterval = time.Minute * 15
+
+func (h *PlayerHandler) NicknameSync(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	enabled := util.BoolOptionByName(EnabledOptionName, i.ApplicationCommandData().Options)
+	if enabled == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib an, ob dein Nickname automatisch aktualisiert werden soll.")
+		return
+	}
+
+	nickname, err := h.nicknames.NicknameByDiscordID(i.Member.User.ID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			messages.SendUnknownErr(i)
+			return
+		}
+		nickname = &models.Nickname{DiscordID: i.Member.User.ID}
+	}
+
+	nickname.SyncDisabled = !*enabled
+	if err = h.nicknames.SaveNickname(nickname); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	desc := "Dein Nickname wird nicht mehr automatisch aktualisiert."
+	if *enabled {
+		desc = "Dein Nickname wird nun automatisch aktualisiert, wenn sich dein in-Game Name ändert."
+		if nickname.PlayerTag == "" {
+			desc += " Nutze `/setnick`, um festzulegen, welcher Account für deinen Nickname verwendet wird."
+		}
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Einstellung gespeichert",
+		desc,
+		messages.ColorGreen,
+	))
+}
+
+func (h *PlayerHandler) NicknameReport(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	clanTags, err := h.auth.ClanTagsWithRole(i, types.AuthRoleCoLeader)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	if len(clanTags) == 0 {
+		messages.SendErr(i, "Nur Vize-Anführer und Anführer können diesen Bericht einsehen.")
+		return
+	}
+
+	nicknames, err := h.nicknames.FailedNicknames()
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.NicknameReportEmbed(nicknames))
+}
+
+// saveNickname stores how the nickname of the user was built. The sync setting of the user is kept.
+func (h *PlayerHandler) saveNickname(discordID, playerTag, alias, appliedNick string) {
+	nickname, err := h.nicknames.NicknameByDiscordID(discordID)
+	if err != nil {
+		nickname = &models.Nickname{DiscordID: discordID}
+	}
+
+	nickname.PlayerTag = playerTag
+	nickname.Alias = alias
+	nickname.AppliedNick = appliedNick
+	nickname.FailedAt = nil
+	nickname.FailureReason = ""
+	if err = h.nicknames.SaveNickname(nickname); err != nil {
+		slog.Error("Error while saving nickname.", slog.Any("err", err), slog.String("discordID", discordID))
+	}
+}
+
+// syncNicknames periodically updates the nicknames of all users whose in-game name has changed.
+func (h *PlayerHandler) syncNicknames() {
+	for range time.Tick(nicknameSyncInterval) {
+		nicknames, err := h.nicknames.SyncedNicknames()
+		if err != nil {
+			slog.Error("Error while getting nicknames to sync.", slog.Any("err", err))
+			continue
+		}
+
+		var updated int
+		for _, nickname := range nicknames {
+			// the account was unlinked or moved to another user
+			if nickname.Player == nil || nickname.Player.DiscordID != nickname.DiscordID {
+				nickname.PlayerTag = ""
+				if err = h.nicknames.SaveNickname(nickname); err != nil {
+					slog.Error("Error while resetting nickname.", slog.Any("err", err))
+				}
+				continue
+			}
+			if nickname.Player.LeftServerAt != nil {
+				continue
+			}
+
+			nick := nickname.Build(nickname.Player.Name)
+			if nick == nickname.AppliedNick {
+				continue
+			}
+
+			if err = util.Session.GuildMemberNickname(env.DISCORD_GUILD_ID.Value(), nickname.DiscordID, nick); err != nil {
+				if nickname.FailedAt == nil {
+					now := time.Now()
+					nickname.FailedAt = &now
+				}
+				nickname.FailureReason = nicknameFailureReason(nick, err)
+			} else {
+				nickname.AppliedNick = nick
+				nickname.FailedAt = nil
+				nickname.FailureReason = ""
+				updated++
+			}
+
+			if err = h.nicknames.SaveNickname(nickname); err != nil {
+				slog.Error("Error while saving nickname.", slog.Any("err", err))
+			}
+		}
+
+		if updated > 0 {
+			slog.Info("Synced nicknames.", slog.Int("amount", updated))
+		}
+	}
+}
+
+func nicknameFailureReason(nick string, err error) string {
+	if utf8.RuneCountInString(nick) > 32 {
+		return "Der Nickname ist länger als 32 Zeichen."
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Message != nil {
+		reason := []rune(fmt.Sprintf("Discord Fehler %d: %s", restErr.Message.Code, restErr.Message.Message))
+		return string(reason[:min(len(reason), 100)])
+	}
+	return "Unbekannter Fehler."
+}
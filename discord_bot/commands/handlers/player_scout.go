@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/types"
+)
+
+func (h *PlayerHandler) Scout(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	playerTag := util.StringOptionByName(PlayerTagOptionName, i.ApplicationCommandData().Options)
+	if playerTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Spieler-Tag an.")
+		return
+	}
+	if !strings.HasPrefix(playerTag, "#") {
+		playerTag = "#" + playerTag
+	}
+
+	// the family history contains kickpoints, so only leaders may scout players
+	clanTags, err := h.auth.ClanTagsWithRole(i, types.AuthRoleCoLeader)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	if len(clanTags) == 0 {
+		messages.SendErr(i, "Nur Vize-Anführer und Anführer können Spieler scouten.")
+		return
+	}
+
+	clashPlayer, err := h.clashClient.GetPlayer(playerTag)
+	if err != nil {
+		messages.SendCocApiErr(i, err)
+		return
+	}
+
+	history, err := h.members.MemberHistory(clashPlayer.Tag)
+	if err != nil {
+		slog.Error("Error while getting member history.", slog.Any("err", err), slog.String("playerTag", clashPlayer.Tag))
+	}
+
+	kickpoints, err := h.kickpoints.KickpointHistory(clashPlayer.Tag)
+	if err != nil {
+		slog.Error("Error while getting kickpoint history.", slog.Any("err", err), slog.String("playerTag", clashPlayer.Tag))
+	}
+
+	messages.SendEphemeralEmbedResponse(i, messages.ScoutEmbed(&types.PlayerScout{
+		ClashPlayer: clashPlayer,
+		Heroes:      util.ScoutHeroes(clashPlayer),
+		History:     history,
+		Kickpoints:  kickpoints,
+	}))
+}
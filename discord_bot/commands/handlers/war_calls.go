@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+func (h *WarHandler) Call(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	target := util.IntOptionByName(TargetOptionName, opts)
+	if clanTag == "" || target == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und die Position der Base an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleMember); err != nil {
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	clanWar, ok := h.currentWar(i, clanTag)
+	if !ok {
+		return
+	}
+
+	targetIndex := slices.IndexFunc(clanWar.Opponent.Members, func(member goclash.ClanWarMember) bool {
+		return member.MapPosition == *target
+	})
+	if targetIndex == -1 {
+		messages.SendInvalidInputErr(i, fmt.Sprintf("Der Gegner hat keine Base auf Position %d.", *target))
+		return
+	}
+	targetMember := clanWar.Opponent.Members[targetIndex]
+	if targetMember.BestOpponentAttack != nil && targetMember.BestOpponentAttack.Stars == 3 {
+		messages.SendErr(i, fmt.Sprintf("%d. %s wurde bereits mit 3 Sternen angegriffen.", targetMember.MapPosition, targetMember.Name))
+		return
+	}
+
+	attacker, msg := h.callingWarMember(i, clanWar, util.StringOptionByName(MyPlayerTagOptionName, opts))
+	if attacker == nil {
+		messages.SendErr(i, msg)
+		return
+	}
+	if len(attacker.Attacks) >= util.WarAttacksPerMember {
+		messages.SendErr(i, fmt.Sprintf("%s hat bereits alle Angriffe genutzt.", attacker.Name))
+		return
+	}
+	if attacker.TownHallLevel-targetMember.TownHallLevel > settings.WarCallMaxTownHallsBelow {
+		messages.SendErr(i, fmt.Sprintf("%s (RH%d) darf höchstens %d Rathaus-Level nach unten callen, %d. %s ist RH%d.", attacker.Name, attacker.TownHallLevel, settings.WarCallMaxTownHallsBelow, targetMember.MapPosition, targetMember.Name, targetMember.TownHallLevel))
+		return
+	}
+
+	war, err := h.clanWar(clanTag, clanWar)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	if war.ID == 0 {
+		war.State = clanWar.State
+		if err = h.wars.SaveClanWar(war); err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+	}
+
+	calls, err := h.wars.ActiveWarCalls(war.ID)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	for _, call := range calls {
+		if call.TargetPosition == *target && call.PlayerTag != attacker.Tag {
+			messages.SendErr(i, fmt.Sprintf("%d. %s wurde bereits von %s gecallt (bis %s).", targetMember.MapPosition, targetMember.Name, call.PlayerName, util.FormatDateTime(call.ExpiresAt)))
+			return
+		}
+	}
+
+	call := &models.WarCall{
+		ClanWarID:         war.ID,
+		TargetPosition:    *target,
+		PlayerTag:         attacker.Tag,
+		PlayerName:        attacker.Name,
+		CalledByDiscordID: i.Member.User.ID,
+		ExpiresAt:         time.Now().Add(time.Duration(settings.WarCallExpiryMinutes) * time.Minute),
+	}
+	if err = h.wars.CreateWarCall(call); err != nil {
+		messages.SendErr(i, "Die Base konnte nicht gecallt werden. Möglicherweise wurde sie gerade von jemand anderem gecallt.")
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Base gecallt",
+		fmt.Sprintf("%s hat %d. %s (RH%d) bis %s gecallt.", attacker.Name, targetMember.MapPosition, targetMember.Name, targetMember.TownHallLevel, util.FormatDateTime(call.ExpiresAt)),
+		messages.ColorGreen,
+	))
+	h.refreshWarBoard(settings, war, clanWar)
+}
+
+func (h *WarHandler) Uncall(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	target := util.IntOptionByName(TargetOptionName, opts)
+	if clanTag == "" || target == nil {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und die Position der Base an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleMember); err != nil {
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	clanWar, ok := h.currentWar(i, clanTag)
+	if !ok {
+		return
+	}
+
+	war, err := h.clanWar(clanTag, clanWar)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	var calls []*models.WarCall
+	if war.ID != 0 {
+		if calls, err = h.wars.ActiveWarCalls(war.ID); err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+	}
+
+	callIndex := slices.IndexFunc(calls, func(call *models.WarCall) bool {
+		return call.TargetPosition == *target
+	})
+	if callIndex == -1 {
+		messages.SendErr(i, fmt.Sprintf("Die Base auf Position %d ist nicht gecallt.", *target))
+		return
+	}
+	call := calls[callIndex]
+
+	// co-leaders may release calls of other members
+	if call.CalledByDiscordID != i.Member.User.ID {
+		clanTags, err := h.auth.ClanTagsWithRole(i, types.AuthRoleCoLeader)
+		if err != nil {
+			messages.SendUnknownErr(i)
+			return
+		}
+		if !slices.Contains(clanTags, clanTag) {
+			messages.SendErr(i, fmt.Sprintf("Die Base wurde von %s gecallt. Nur Vize-Anführer können fremde Calls entfernen.", call.PlayerName))
+			return
+		}
+	}
+
+	if err = h.wars.DeleteWarCall(call.ID); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.NewEmbed(
+		"Call entfernt",
+		fmt.Sprintf("Der Call von %s auf Position %d wurde entfernt.", call.PlayerName, call.TargetPosition),
+		messages.ColorGreen,
+	))
+	h.refreshWarBoard(settings, war, clanWar)
+}
+
+func (h *WarHandler) WarCallSettings(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	// only the given settings are changed
+	if expiry := util.IntOptionByName(ExpiryOptionName, opts); expiry != nil {
+		settings.WarCallExpiryMinutes = *expiry
+	}
+	if townHallsBelow := util.IntOptionByName(TownHallsBelowOptionName, opts); townHallsBelow != nil {
+		settings.WarCallMaxTownHallsBelow = *townHallsBelow
+	}
+
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.WarSettingsEmbed(clanName, settings))
+}
+
+// currentWar returns the current war of the clan and sends an error message if the clan is neither in preparation nor on battle day.
+func (h *WarHandler) currentWar(i *discordgo.InteractionCreate, clanTag string) (*goclash.ClanWar, bool) {
+	clanWar, err := h.clashClient.GetCurrentClanWar(clanTag)
+	if err != nil {
+		messages.SendCocApiErr(i, err)
+		return nil, false
+	}
+	if clanWar.State != goclash.ClanWarStatePreparation && clanWar.State != goclash.ClanWarStateInWar {
+		messages.SendErr(i, "Der Clan befindet sich aktuell in keinem Clan Krieg.")
+		return nil, false
+	}
+	return clanWar, true
+}
+
+// callingWarMember returns the war member of the user who is calling. Users with several accounts in the war have to pick one.
+// If no member is found, a message describing the problem is returned.
+func (h *WarHandler) callingWarMember(i *discordgo.InteractionCreate, clanWar *goclash.ClanWar, playerTag string) (*goclash.ClanWarMember, string) {
+	players, err := h.players.PlayersByDiscordID(i.Member.User.ID)
+	if err != nil {
+		return nil, "Es ist ein unbekannter Fehler aufgetreten."
+	}
+	if playerTag != "" && !strings.HasPrefix(playerTag, "#") {
+		playerTag = "#" + playerTag
+	}
+
+	var warMembers []*goclash.ClanWarMember
+	for index := range clanWar.Clan.Members {
+		member := &clanWar.Clan.Members[index]
+		if playerTag != "" && !strings.EqualFold(member.Tag, playerTag) {
+			continue
+		}
+		if slices.ContainsFunc(players, func(player *models.Player) bool { return player.CocTag == member.Tag }) {
+			warMembers = append(warMembers, member)
+		}
+	}
+
+	switch {
+	case len(warMembers) == 1:
+		return warMembers[0], ""
+	case len(warMembers) > 1:
+		return nil, fmt.Sprintf("Du hast mehrere Accounts im Krieg. Bitte wähle mit `%s` aus, welcher Account callt.", MyPlayerTagOptionName)
+	case playerTag != "":
+		return nil, fmt.Sprintf("Der Account %s ist nicht mit deinem Discord Account verknüpft oder nicht im Krieg.", playerTag)
+	default:
+		return nil, "Keiner deiner verknüpften Accounts ist im Krieg."
+	}
+}
+
+// refreshWarBoard updates the call board after a call has changed, if the clan has a war channel.
+func (h *WarHandler) refreshWarBoard(settings *models.ClanSettings, war *models.ClanWar, clanWar *goclash.ClanWar) {
+	channelID := settings.ChannelID(models.ClanChannelWar)
+	if channelID == "" {
+		return
+	}
+
+	if err := h.updateWarBoard(channelID, war, clanWar); err != nil {
+		slog.Error("Error while updating war board.", slog.Any("err", err), slog.String("clanTag", settings.ClanTag))
+	}
+}
+
+// updateWarBoard edits the call board of the war, or posts a new one if there is none yet or it has been deleted.
+// The message of a new board is stored right away, unless the war has not been saved yet.
+func (h *WarHandler) updateWarBoard(channelID string, war *models.ClanWar, clanWar *goclash.ClanWar) error {
+	var calls []*models.WarCall
+	if war.ID != 0 {
+		var err error
+		if calls, err = h.wars.ActiveWarCalls(war.ID); err != nil {
+			return err
+		}
+	}
+
+	embed := messages.WarBoardEmbed(clanWar, calls)
+	if war.BoardMessageID != "" {
+		_, err := util.Session.ChannelMessageEditEmbed(channelID, war.BoardMessageID, embed)
+		var restErr *discordgo.RESTError
+		if err == nil || !errors.As(err, &restErr) || restErr.Message == nil || restErr.Message.Code != discordgo.ErrCodeUnknownMessage {
+			return err
+		}
+	}
+
+	msg, err := util.Session.ChannelMessageSendEmbed(channelID, embed)
+	if err != nil {
+		return err
+	}
+	if war.ID == 0 {
+		war.BoardMessageID = msg.ID
+		return nil
+	}
+
+	updated, err := h.wars.UpdateWarBoardMessage(war.ID, war.BoardMessageID, msg.ID)
+	if err != nil || !updated {
+		// another board has been posted in the meantime, or the board could not be stored
+		if deleteErr := util.Session.ChannelMessageDelete(channelID, msg.ID); deleteErr != nil {
+			slog.Error("Error while deleting duplicate war board.", slog.Any("err", deleteErr), slog.String("channelID", channelID))
+		}
+		return err
+	}
+	war.BoardMessageID = msg.ID
+	return nil
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/messages"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/commands/validation"
+	"bot/types"
+)
+
+type IWarHandler interface {
+	WarReminders(s *discordgo.Session, i *discordgo.InteractionCreate)
+	CWDonorSettings(s *discordgo.Session, i *discordgo.InteractionCreate)
+	WarCallSettings(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Call(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Uncall(s *discordgo.Session, i *discordgo.InteractionCreate)
+	WarStats(s *discordgo.Session, i *discordgo.InteractionCreate)
+	WarLog(s *discordgo.Session, i *discordgo.InteractionCreate)
+	WarScout(s *discordgo.Session, i *discordgo.InteractionCreate)
+	HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate)
+}
+
+const defaultWarCount = 10
+
+type WarHandler struct {
+	clans        repos.IClansRepo
+	players      repos.IPlayersRepo
+	members      repos.IMembersRepo
+	clanSettings repos.IClanSettingsRepo
+	wars         repos.IWarsRepo
+	absences     repos.IAbsencesRepo
+	cwDonors     repos.ICWDonorsRepo
+	auth         middleware.AuthMiddleware
+	clashClient  *goclash.Client
+
+	// lastSeenWars holds the last polled war of every clan, used to finish wars which were replaced before the tracker saw them end.
+	// It is only accessed by trackWars.
+	lastSeenWars map[string]*goclash.ClanWar
+}
+
+func NewWarHandler(clans repos.IClansRepo, players repos.IPlayersRepo, members repos.IMembersRepo, clanSettings repos.IClanSettingsRepo, wars repos.IWarsRepo, absences repos.IAbsencesRepo, cwDonors repos.ICWDonorsRepo, auth middleware.AuthMiddleware, clashClient *goclash.Client) IWarHandler {
+	h := &WarHandler{
+		clans:        clans,
+		players:      players,
+		members:      members,
+		clanSettings: clanSettings,
+		wars:         wars,
+		absences:     absences,
+		cwDonors:     cwDonors,
+		auth:         auth,
+		clashClient:  clashClient,
+		lastSeenWars: make(map[string]*goclash.ClanWar),
+	}
+
+	go h.trackWars()
+
+	return h
+}
+
+func (h *WarHandler) WarReminders(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	hours := strings.ReplaceAll(util.StringOptionByName(HoursOptionName, opts), " ", "")
+	if clanTag == "" || hours == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan und die Stunden vor Kriegsende an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	// 0 disables the reminders
+	if hours == "0" {
+		hours = ""
+	} else if msg, ok := validation.ValidateWarReminderHours(hours); !ok {
+		messages.SendInvalidInputErr(i, msg)
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	settings.WarReminderHours = hours
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.WarSettingsEmbed(clanName, settings))
+}
+
+func (h *WarHandler) CWDonorSettings(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	settings, err := h.clanSettings.ClanSettings(clanTag)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	// only the given settings are changed
+	if autoPost := util.BoolOptionByName(AutoPostOptionName, opts); autoPost != nil {
+		settings.CWDonorAutoPost = *autoPost
+	}
+	if rangeSize := util.IntOptionByName(RangeSizeOptionName, opts); rangeSize != nil {
+		settings.CWDonorRangeSize = *rangeSize
+	}
+
+	settings.UpdatedByDiscordID = &i.Member.User.ID
+	if err = h.clanSettings.UpdateClanSettings(settings); err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.WarSettingsEmbed(clanName, settings))
+}
+
+func (h *WarHandler) WarStats(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	warCount := defaultWarCount
+	if count := util.IntOptionByName(WarsOptionName, opts); count != nil {
+		warCount = *count
+	}
+
+	wars, err := h.wars.FinishedWars(clanTag, warCount, "Participants", "Attacks")
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+	stats := util.WarPlayerStatistics(wars)
+
+	playerTag := util.StringOptionByName(PlayerTagOptionName, opts)
+	if playerTag == "" {
+		messages.SendEmbedResponse(i, messages.WarStatsEmbed(clanName, len(wars), stats))
+		return
+	}
+
+	if !strings.HasPrefix(playerTag, "#") {
+		playerTag = "#" + playerTag
+	}
+	for _, s := range stats {
+		if s.PlayerTag == strings.ToUpper(playerTag) {
+			messages.SendEmbedResponse(i, messages.WarPlayerStatsEmbed(clanName, len(wars), s))
+			return
+		}
+	}
+	messages.SendErr(i, fmt.Sprintf("%s hat in den letzten %d Kriegen von %s nicht teilgenommen.", playerTag, len(wars), clanName))
+}
+
+func (h *WarHandler) WarLog(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options
+	clanTag := util.StringOptionByName(ClanTagOptionName, opts)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	clanName, err := h.clans.ClanNameByTag(clanTag)
+	if err != nil {
+		messages.SendClanNotFound(i, clanTag)
+		return
+	}
+
+	warCount := defaultWarCount
+	if count := util.IntOptionByName(WarsOptionName, opts); count != nil {
+		warCount = *count
+	}
+
+	wars, err := h.wars.FinishedWars(clanTag, warCount)
+	if err != nil {
+		messages.SendUnknownErr(i)
+		return
+	}
+
+	messages.SendEmbedResponse(i, messages.WarLogEmbed(clanName, wars))
+}
+
+func (h *WarHandler) HandleAutocomplete(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	for _, opt := range i.ApplicationCommandData().Options {
+		if !opt.Focused {
+			continue
+		}
+
+		switch opt.Name {
+		case ClanTagOptionName:
+			autocompleteClans(i, h.clans, opt.StringValue())
+		case PlayerTagOptionName:
+			autocompleteMembers(i, h.players, opt.StringValue(), util.StringOptionByName(ClanTagOptionName, i.ApplicationCommandData().Options))
+		case MyPlayerTagOptionName:
+			autocompleteMyPlayers(i, h.players, opt.StringValue())
+		}
+	}
+}
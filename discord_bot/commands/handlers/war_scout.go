@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/types"
+)
+
+func (h *WarHandler) WarScout(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	clanTag := util.StringOptionByName(ClanTagOptionName, i.ApplicationCommandData().Options)
+	if clanTag == "" {
+		messages.SendInvalidInputErr(i, "Bitte gib einen Clan an.")
+		return
+	}
+
+	if err := h.auth.AuthorizeInteraction(i, clanTag, types.AuthRoleCoLeader); err != nil {
+		return
+	}
+
+	clanWar, ok := h.currentWar(i, clanTag)
+	if !ok {
+		return
+	}
+
+	// fetching the live data of both lineups may take longer than 3 seconds
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		slog.Error("Failed to send deferred response", slog.Any("err", err))
+		return
+	}
+
+	tags := make([]string, 0, len(clanWar.Clan.Members)+len(clanWar.Opponent.Members))
+	for _, member := range clanWar.Clan.Members {
+		tags = append(tags, member.Tag)
+	}
+	for _, member := range clanWar.Opponent.Members {
+		tags = append(tags, member.Tag)
+	}
+
+	// players which could not be fetched are shown without hero levels and war stars
+	players := h.clashClient.GetPlayers(tags...)
+	playerByTag := make(map[string]*goclash.Player, len(players))
+	for _, player := range players {
+		if player != nil {
+			playerByTag[player.Tag] = player
+		}
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{messages.WarScoutEmbed(clanWar, util.ScoutWar(clanWar, playerByTag))},
+	}); err != nil {
+		slog.Error("Failed to edit message.", slog.Any("err", err))
+	}
+}
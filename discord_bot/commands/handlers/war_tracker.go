@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/messages"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+const (
+	warTrackInterval = time.Minute * 5
+
+	// warThreadArchiveMinutes is the inactivity after which Discord archives a war thread. Wars are archived by the tracker when they end.
+	warThreadArchiveMinutes = 4320
+
+	// replacedWarRetryDuration is how long after its end the result of a replaced war is looked up in the war log, before the war is closed without result.
+	replacedWarRetryDuration = time.Hour * 24
+)
+
+var errWarNotInLog = errors.New("war not found in war log")
+
+// trackWars periodically follows the current war of every clan and stores it when it has ended.
+// For clans with a war channel, it posts the lineup and the donors, keeps the call board up to date,
+// reminds members with unused attacks and posts the result. For clans with a war thread channel,
+// it opens a thread for every war holding the lineup, the donors and a log of all attacks.
+func (h *WarHandler) trackWars() {
+	for range time.Tick(warTrackInterval) {
+		clans, err := h.clans.AllClans()
+		if err != nil {
+			slog.Error("Error while getting clans to track wars.", slog.Any("err", err))
+			continue
+		}
+
+		for _, clan := range clans {
+			settings, err := h.clanSettings.ClanSettings(clan.Tag)
+			if err == nil {
+				err = h.trackWar(settings)
+			}
+			if err != nil {
+				slog.Error("Error while tracking war.", slog.Any("err", err), slog.String("clanTag", clan.Tag))
+			}
+		}
+	}
+}
+
+func (h *WarHandler) trackWar(settings *models.ClanSettings) error {
+	clanWar, err := h.clashClient.GetCurrentClanWar(settings.ClanTag)
+	if err != nil {
+		return err
+	}
+
+	if err = h.finishReplacedWars(settings, clanWar); err != nil {
+		slog.Error("Error while finishing replaced wars.", slog.Any("err", err), slog.String("clanTag", settings.ClanTag))
+	}
+
+	ended := util.WarEnded(clanWar)
+	if clanWar.State != goclash.ClanWarStatePreparation && clanWar.State != goclash.ClanWarStateInWar && !ended {
+		return nil
+	}
+
+	war, err := h.clanWar(settings.ClanTag, clanWar)
+	if err != nil {
+		return err
+	}
+
+	channelID := settings.ChannelID(models.ClanChannelWar)
+	now := time.Now()
+
+	// like the lineup, the thread is not opened anymore if the bot only sees the war after it has ended.
+	// If the thread cannot be opened, the war is tracked in the war channel and opening it is retried with the next update.
+	if threadChannelID := settings.ChannelID(models.ClanChannelWarThread); threadChannelID != "" && war.ThreadID == "" && !ended {
+		thread, err := util.Session.ThreadStart(threadChannelID, messages.WarThreadName(clanWar), discordgo.ChannelTypeGuildPublicThread, warThreadArchiveMinutes)
+		if err != nil {
+			slog.Error("Error while opening war thread.", slog.Any("err", err), slog.String("clanTag", settings.ClanTag))
+		} else {
+			// saved right away, so that a failure later on does not open a second thread
+			war.ThreadID = thread.ID
+			if err = h.wars.SaveClanWar(war); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the lineup and the donors are posted in the thread of the war instead of the war channel
+	lineupChannelID := channelID
+	if war.ThreadID != "" {
+		lineupChannelID = war.ThreadID
+	}
+
+	// failed posts are logged and retried with the next update, the war is saved anyway so that the successful ones are not repeated.
+	// The lineup is not posted anymore if the bot only sees the war after it has ended.
+	if lineupChannelID != "" && war.LineupPostedAt == nil && !ended {
+		if _, err = util.Session.ChannelMessageSendEmbed(lineupChannelID, messages.WarLineupEmbed(clanWar, war.StartTime, war.EndTime)); err != nil {
+			slog.Error("Error while posting war lineup.", slog.Any("err", err), slog.String("clanTag", settings.ClanTag))
+		} else {
+			war.LineupPostedAt = &now
+		}
+	}
+
+	if lineupChannelID != "" && settings.CWDonorAutoPost && war.DonorsPostedAt == nil && clanWar.State == goclash.ClanWarStatePreparation {
+		if err = h.postCWDonors(lineupChannelID, settings, clanWar); err != nil {
+			slog.Error("Error while posting CW donors.", slog.Any("err", err), slog.String("clanTag", settings.ClanTag))
+		} else {
+			war.DonorsPostedAt = &now
+		}
+	}
+
+	if war.ThreadID != "" && (clanWar.State == goclash.ClanWarStateInWar || ended) {
+		h.logWarAttacks(war, clanWar)
+	}
+
+	if channelID != "" && clanWar.State == goclash.ClanWarStateInWar && warReminderDue(settings, war, now) {
+		h.sendWarReminder(channelID, clanWar, war.EndTime.Sub(now))
+		war.LastReminderAt = &now
+	}
+
+	// the board is posted when the battle day starts, unless a call already posted it, and shows the final stars once more at the end
+	if channelID != "" && (clanWar.State == goclash.ClanWarStateInWar || (ended && war.ResultPostedAt == nil && war.BoardMessageID != "")) {
+		if err = h.updateWarBoard(channelID, war, clanWar); err != nil {
+			slog.Error("Error while updating war board.", slog.Any("err", err), slog.String("clanTag", settings.ClanTag))
+		}
+	}
+
+	if channelID != "" && ended && war.ResultPostedAt == nil {
+		if _, err = util.Session.ChannelMessageSendEmbed(channelID, messages.WarResultEmbed(clanWar)); err != nil {
+			slog.Error("Error while posting war result.", slog.Any("err", err), slog.String("clanTag", settings.ClanTag))
+		} else {
+			war.ResultPostedAt = &now
+		}
+	}
+
+	h.lastSeenWars[settings.ClanTag] = clanWar
+	war.State = clanWar.State
+	if ended && war.Result == "" {
+		if war.ThreadID != "" {
+			h.closeWarThread(war.ThreadID, clanWar)
+		}
+
+		util.ApplyWarResult(war, clanWar)
+		return h.wars.SaveFinishedWar(war)
+	}
+	return h.wars.SaveClanWar(war)
+}
+
+// finishReplacedWars finishes the stored wars of the clan which have ended, but were replaced by the next war
+// before the tracker saw them in the ended state, e.g. because the clan started searching right after the end.
+func (h *WarHandler) finishReplacedWars(settings *models.ClanSettings, clanWar *goclash.ClanWar) error {
+	wars, err := h.wars.UnfinishedWars(settings.ClanTag, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// zero if the clan is not in a war
+	preparationStartTime, _ := util.ParseClashDate(clanWar.PreparationStartTime)
+	for _, war := range wars {
+		// the current war is finished by trackWar
+		if war.PreparationStartTime.Equal(preparationStartTime) {
+			continue
+		}
+		if err = h.finishReplacedWar(settings, war); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishReplacedWar posts the result of the war, archives its thread and stores it as finished.
+// The result is taken from the last polled state of the war, which misses at most the attacks of the last minutes before the end.
+// If the bot was restarted in between, the result is taken from the war log, which has no lineup and attacks.
+func (h *WarHandler) finishReplacedWar(settings *models.ClanSettings, war *models.ClanWar) error {
+	clanWar := h.lastSeenWars[settings.ClanTag]
+	if clanWar == nil || !sameWar(war, clanWar) {
+		var err error
+		if clanWar, err = h.warFromWarLog(war); err != nil {
+			if time.Since(war.EndTime) < replacedWarRetryDuration {
+				return err
+			}
+			slog.Warn("Result of replaced war not found, it is closed without result.", slog.Any("err", err), slog.String("clanTag", war.ClanTag))
+			clanWar = nil
+		}
+	}
+
+	war.State = goclash.ClanWarStateEnded
+	if clanWar == nil {
+		if war.ThreadID != "" {
+			archiveWarThread(war.ThreadID)
+		}
+		return h.wars.SaveClanWar(war)
+	}
+	clanWar.State = goclash.ClanWarStateEnded
+
+	if channelID := settings.ChannelID(models.ClanChannelWar); channelID != "" && war.ResultPostedAt == nil {
+		if _, err := util.Session.ChannelMessageSendEmbed(channelID, messages.WarResultEmbed(clanWar)); err != nil {
+			slog.Error("Error while posting result of replaced war.", slog.Any("err", err), slog.String("clanTag", war.ClanTag))
+		} else {
+			now := time.Now()
+			war.ResultPostedAt = &now
+		}
+	}
+	if war.ThreadID != "" {
+		h.closeWarThread(war.ThreadID, clanWar)
+	}
+
+	util.ApplyWarResult(war, clanWar)
+	return h.wars.SaveFinishedWar(war)
+}
+
+// warFromWarLog returns the entry of the war in the war log of the clan as a war without lineup.
+func (h *WarHandler) warFromWarLog(war *models.ClanWar) (*goclash.ClanWar, error) {
+	log, err := h.clashClient.GetClanWarLog(war.ClanTag, &goclash.PagingParams{Limit: 10})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range log.Items {
+		endTime, err := util.ParseClashDate(entry.EndTime)
+		if err != nil || !endTime.Equal(war.EndTime) || entry.Opponent.Tag != war.OpponentTag {
+			continue
+		}
+		return &goclash.ClanWar{
+			Clan:     entry.Clan,
+			Opponent: entry.Opponent,
+			TeamSize: entry.TeamSize,
+			EndTime:  entry.EndTime,
+		}, nil
+	}
+	return nil, errWarNotInLog
+}
+
+// sameWar reports whether the polled war is the stored war.
+func sameWar(war *models.ClanWar, clanWar *goclash.ClanWar) bool {
+	preparationStartTime, err := util.ParseClashDate(clanWar.PreparationStartTime)
+	return err == nil && preparationStartTime.Equal(war.PreparationStartTime)
+}
+
+// clanWar returns the stored war matching the current war of the clan, or a new one.
+func (h *WarHandler) clanWar(clanTag string, clanWar *goclash.ClanWar) (*models.ClanWar, error) {
+	preparationStartTime, err := util.ParseClashDate(clanWar.PreparationStartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	war, err := h.wars.ClanWar(clanTag, preparationStartTime)
+	if err == nil {
+		return war, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	startTime, err := util.ParseClashDate(clanWar.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := util.ParseClashDate(clanWar.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ClanWar{
+		ClanTag:              clanTag,
+		PreparationStartTime: preparationStartTime,
+		StartTime:            startTime,
+		EndTime:              endTime,
+		OpponentTag:          clanWar.Opponent.Tag,
+		OpponentName:         clanWar.Opponent.Name,
+		TeamSize:             clanWar.TeamSize,
+	}, nil
+}
+
+// postCWDonors selects the donors of the war like /cwdonator and posts them together with the reroll buttons.
+func (h *WarHandler) postCWDonors(channelID string, settings *models.ClanSettings, clanWar *goclash.ClanWar) error {
+	members, err := h.members.MembersByClanTag(settings.ClanTag)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := cwDonorCandidates(h.clashClient, h.cwDonors, h.absences, settings.ClanTag, clanWar.Clan.Members, members)
+	if err != nil {
+		return err
+	}
+
+	donors := util.SelectCWDonors(len(clanWar.Clan.Members), settings.CWDonorRangeSize, candidates)
+	if len(donors) == 0 {
+		return nil
+	}
+
+	if err = saveCWDonors(h.cwDonors, settings.ClanTag, clanWar.PreparationStartTime, donors); err != nil {
+		return err
+	}
+
+	_, err = util.Session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content:    fmt.Sprintf("**CW Spender gegen %s**\n%s", clanWar.Opponent.Name, messages.CWDonatorPing(donors)),
+		Components: cwDonorRerollButtons(settings.ClanTag, clanWar.PreparationStartTime, donors),
+	})
+	return err
+}
+
+// logWarAttacks posts the attacks made since the last update in the thread of the war.
+func (h *WarHandler) logWarAttacks(war *models.ClanWar, clanWar *goclash.ClanWar) {
+	log, lastOrder := messages.WarAttackLog(clanWar, war.LastLoggedAttack)
+	for _, content := range log {
+		if _, err := util.Session.ChannelMessageSend(war.ThreadID, content); err != nil {
+			// the attacks are posted again with the next update
+			slog.Error("Error while logging war attacks.", slog.Any("err", err), slog.String("threadID", war.ThreadID))
+			return
+		}
+	}
+	war.LastLoggedAttack = lastOrder
+}
+
+// closeWarThread posts the result in the thread of the war and archives it.
+func (h *WarHandler) closeWarThread(threadID string, clanWar *goclash.ClanWar) {
+	if _, err := util.Session.ChannelMessageSendEmbed(threadID, messages.WarResultEmbed(clanWar)); err != nil {
+		slog.Error("Error while posting war result in thread.", slog.Any("err", err), slog.String("threadID", threadID))
+	}
+
+	archiveWarThread(threadID)
+}
+
+func archiveWarThread(threadID string) {
+	archived := true
+	if _, err := util.Session.ChannelEdit(threadID, &discordgo.ChannelEdit{Archived: &archived}); err != nil {
+		slog.Error("Error while archiving war thread.", slog.Any("err", err), slog.String("threadID", threadID))
+	}
+}
+
+// warReminderDue reports whether a configured reminder has passed since the last reminder was sent.
+func warReminderDue(settings *models.ClanSettings, war *models.ClanWar, now time.Time) bool {
+	if !now.Before(war.EndTime) {
+		return false
+	}
+
+	var due *time.Time
+	for _, hours := range settings.WarReminders() {
+		reminderAt := war.EndTime.Add(-time.Duration(hours) * time.Hour)
+		if reminderAt.After(now) {
+			break
+		}
+		due = &reminderAt
+	}
+
+	return due != nil && (war.LastReminderAt == nil || war.LastReminderAt.Before(*due))
+}
+
+func (h *WarHandler) sendWarReminder(channelID string, clanWar *goclash.ClanWar, remaining time.Duration) {
+	members, err := h.members.MembersByClanTag(clanWar.Clan.Tag)
+	if err != nil {
+		slog.Error("Error while getting members for war reminder.", slog.Any("err", err), slog.String("clanTag", clanWar.Clan.Tag))
+	}
+
+	memberByTag := make(map[string]*models.ClanMember, len(members))
+	for _, member := range members {
+		memberByTag[member.PlayerTag] = member
+	}
+
+	tags := make([]string, len(clanWar.Clan.Members))
+	for index, member := range clanWar.Clan.Members {
+		tags[index] = member.Tag
+	}
+
+	content := messages.WarReminder(clanWar, remaining, memberByTag, activeAbsences(h.absences, tags))
+	if content == "" {
+		return
+	}
+
+	if _, err = util.Session.ChannelMessageSend(channelID, content); err != nil {
+		slog.Error("Error while sending war reminder.", slog.Any("err", err), slog.String("channelID", channelID))
+	}
+}
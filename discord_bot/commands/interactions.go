@@ -21,6 +21,12 @@ func createInteractions(db *gorm.DB, clashClient *goclash.Client) types.Commands
 		memberInteractionCommands(db, clashClient),
 		adminInteractionCommands(db),
 		clanInteractionCommands(db, clashClient),
+		noteInteractionCommands(db),
+		guildMemberInteractionCommands(db),
+		blacklistInteractionCommands(db),
+		absenceInteractionCommands(db),
+		warInteractionCommands(db, clashClient),
+		cwlInteractionCommands(db, clashClient),
 	}
 
 	var flat types.Commands[types.InteractionHandler]
@@ -92,6 +98,18 @@ func interactionHandler(interactions types.Commands[types.InteractionHandler]) f
 				slog.Info("Modal submit handler was executed.", slog.String("command", commandName), slog.String("username", i.Member.User.Username))
 				return
 			}
+
+		case discordgo.InteractionMessageComponent:
+			commandName, _, _, _ := util.ParseComponentID(i.MessageComponentData().CustomID)
+			if command, ok := commands[commandName]; ok {
+				if command.Handler.Component == nil {
+					slog.Error("Tried to run component handler but it is nil.", slog.String("command", commandName), slog.String("username", i.Member.User.Username))
+					return
+				}
+				command.Handler.Component(s, i)
+				slog.Info("Component handler was executed.", slog.String("command", commandName), slog.String("username", i.Member.User.Username))
+				return
+			}
 		}
 		sendCommandNotFound(i)
 	}
@@ -8,6 +8,7 @@ import (
 	"bot/commands/middleware"
 	"bot/commands/repos"
 	"bot/commands/util"
+	"bot/store/postgres/models"
 	"bot/types"
 )
 
@@ -20,6 +21,7 @@ func kickpointInteractionCommands(db *gorm.DB) types.Commands[types.InteractionH
 		repos.NewMembersRepo(db),
 		repos.NewClanSettingsRepo(db),
 		repos.NewMemberStatesRepo(db),
+		repos.NewNotesRepo(db),
 		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
 	)
 
@@ -77,6 +79,40 @@ func kickpointInteractionCommands(db *gorm.DB) types.Commands[types.InteractionH
 				optionClanTag("Clan, dessen Konfiguration geändert werden soll."),
 			},
 		}}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.ClanChannel,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "clanchannel",
+			Description:  "Legt fest, in welchem Channel der Bot Nachrichten zu einem Clan sendet.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Channel festgelegt werden soll."),
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        handlers.ChannelTypeOptionName,
+					Description: "Art der Nachrichten, die in den Channel gesendet werden sollen.",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: models.ClanChannelLeader.Format(), Value: models.ClanChannelLeader.String()},
+						{Name: models.ClanChannelRecruitment.Format(), Value: models.ClanChannelRecruitment.String()},
+						{Name: models.ClanChannelWar.Format(), Value: models.ClanChannelWar.String()},
+						{Name: models.ClanChannelWarThread.Format(), Value: models.ClanChannelWarThread.String()},
+						{Name: models.ClanChannelRaid.Format(), Value: models.ClanChannelRaid.String()},
+						{Name: models.ClanChannelClanGames.Format(), Value: models.ClanChannelClanGames.String()},
+					},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         handlers.ChannelOptionName,
+					Description:  "Channel, in den die Nachrichten gesendet werden sollen.",
+					Required:     true,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+			},
+		}}, {
 		Handler: types.InteractionHandler{
 			Main:         handler.AddKickpointReason,
 			Autocomplete: handler.HandleAutocomplete,
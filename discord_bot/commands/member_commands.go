@@ -9,6 +9,7 @@ import (
 	"bot/commands/middleware"
 	"bot/commands/repos"
 	"bot/commands/util"
+	"bot/commands/validation"
 	"bot/store/postgres/models"
 	"bot/types"
 )
@@ -19,6 +20,12 @@ func memberInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.C
 		repos.NewClansRepo(db),
 		repos.NewPlayersRepo(db),
 		repos.NewGuildsRepo(db),
+		repos.NewClanSettingsRepo(db),
+		repos.NewKickpointsRepo(db),
+		repos.NewNotesRepo(db),
+		repos.NewBlacklistRepo(db),
+		repos.NewAbsencesRepo(db),
+		repos.NewWarsRepo(db),
 		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
 		clashClient,
 	)
@@ -55,6 +62,7 @@ func memberInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.C
 		Handler: types.InteractionHandler{
 			Main:         handler.AddMember,
 			Autocomplete: handler.HandleAutocomplete,
+			Component:    handler.AddMemberComponent,
 		},
 		ApplicationCommand: &discordgo.ApplicationCommand{
 			Name:         "addmember",
@@ -160,5 +168,141 @@ func memberInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.C
 				},
 			},
 		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Apply,
+			ModalSubmit:  handler.ApplyModalSubmit,
+			Component:    handler.ApplyComponent,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "apply",
+			Description:  "Bewirb dich mit einem deiner Accounts bei einem Clan.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, bei dem du dich bewerben möchtest."),
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         handlers.MyPlayerTagOptionName,
+					Description:  "Spieler-Tag des Accounts, mit dem du dich bewerben möchtest.",
+					Required:     true,
+					MinLength:    util.IntPtr(validation.TagMinLength),
+					MaxLength:    validation.TagMaxLength,
+					Autocomplete: true,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.ClanRequirements,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "clanrequirements",
+			Description:  "Legt die Anforderungen für Bewerbungen bei einem Clan fest. 0 entfernt eine Anforderung.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Anforderungen festgelegt werden sollen."),
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.TownHallOptionName,
+					Description: "Minimales Rathaus-Level.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    20,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.HeroLevelsOptionName,
+					Description: "Minimale Summe der Heldenlevel.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    500,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.WarStarsOptionName,
+					Description: "Minimale Anzahl an Kriegssternen.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    10000,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.LeagueOptionName,
+					Description: "Minimale Liga.",
+					Choices: append(
+						[]*discordgo.ApplicationCommandOptionChoice{{Name: "Keine", Value: 0}},
+						types.HomeLeagueChoices()...,
+					),
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Promotions,
+			Component:    handler.PromotionsComponent,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "promotions",
+			Description:  "Schlägt Mitglieder für eine Beförderung anhand der Kriterien des Clans vor.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Mitglieder geprüft werden sollen."),
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        handlers.RoleOptionName,
+					Description: "Rolle, zu der befördert werden soll.",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: models.RoleElder.Format(), Value: models.RoleElder.String()},
+						{Name: models.RoleCoLeader.Format(), Value: models.RoleCoLeader.String()},
+					},
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.PromotionSettings,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "promotionsettings",
+			Description:  "Legt die Kriterien für Beförderungsvorschläge eines Clans fest.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Kriterien festgelegt werden sollen."),
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.MinDaysOptionName,
+					Description: "Minimale Anzahl an Tagen im Clan.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    365,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.DonationsOptionName,
+					Description: "Minimale Anzahl an Spenden in der aktuellen Season.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    100000,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.AttackRateOptionName,
+					Description: "Minimaler Anteil genutzter Angriffe in den letzten gespeicherten Kriegen in Prozent.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    100,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.KickpointsOptionName,
+					Description: "Maximale Anzahl an Kickpunkten, einschließlich abgelaufener.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    100,
+				},
+			},
+		},
 	}}
 }
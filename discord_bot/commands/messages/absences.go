@@ -0,0 +1,69 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+func AbsencesEmbed(absences []*models.Absence) *discordgo.MessageEmbed {
+	if len(absences) == 0 {
+		return NewEmbed(
+			"Abwesenheiten",
+			"Du hast keine aktuellen oder geplanten Abwesenheiten. Nutze `/absence add`, um eine Abwesenheit einzutragen.",
+			ColorAqua,
+		)
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, min(len(absences), maxEmbedFields))
+	for _, absence := range absences {
+		if len(fields) == maxEmbedFields {
+			break
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("#%d %s", absence.ID, absencePlayerName(absence)),
+			Value: fmt.Sprintf("%s\nGrund: %s", FormatAbsencePeriod(absence), absence.Reason),
+		})
+	}
+
+	return NewFieldEmbed(
+		"Abwesenheiten",
+		"Während einer Abwesenheit wirst du bei Raid Pings und Kriegserinnerungen übersprungen.",
+		ColorAqua,
+		fields,
+	)
+}
+
+// FormatAbsencePeriod formats the first and the last day of the absence.
+func FormatAbsencePeriod(absence *models.Absence) string {
+	return fmt.Sprintf("%s bis %s", util.FormatDate(absence.StartsAt), util.FormatDate(absence.EndsAt.AddDate(0, 0, -1)))
+}
+
+func absencePlayerName(absence *models.Absence) string {
+	if absence.Player != nil {
+		return fmt.Sprintf("%s (%s)", absence.Player.Name, absence.PlayerTag)
+	}
+	return absence.PlayerTag
+}
+
+func absentMembersField(members models.ClanMembers, absenceByTag map[string]*models.Absence) *discordgo.MessageEmbedField {
+	field := &discordgo.MessageEmbedField{}
+	var count int
+	for _, member := range members {
+		absence, ok := absenceByTag[member.PlayerTag]
+		if !ok {
+			continue
+		}
+		count++
+		field.Value += fmt.Sprintf("%s (zurück am %s)\n", member.Player.Name, util.FormatDate(absence.EndsAt))
+	}
+	if count == 0 {
+		return nil
+	}
+
+	field.Name = fmt.Sprintf("Abwesend (%d)", count)
+	return field
+}
@@ -0,0 +1,117 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+func ApplicationEmbed(applicant *discordgo.User, clanName string, player *goclash.Player, requirements []*types.Requirement, answers []*types.ApplicationAnswer) *discordgo.MessageEmbed {
+	color := ColorGreen
+	var b strings.Builder
+	for _, requirement := range requirements {
+		icon := "✅"
+		if !requirement.Met {
+			icon = "❌"
+			color = ColorYellow
+		}
+		b.WriteString(fmt.Sprintf("%s **%s:** %s (min. %s)\n", icon, requirement.Name, requirement.Actual, requirement.Required))
+	}
+	if len(requirements) == 0 {
+		b.WriteString("Für diesen Clan sind keine Anforderungen festgelegt.")
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name: "Account",
+			Value: fmt.Sprintf(
+				"**Rathaus:** %d\n**Helden:** %s\n**Kriegssterne:** %d\n**Liga:** %s",
+				player.TownHallLevel,
+				formatHeroes(player.Heroes),
+				player.WarStars,
+				types.HomeLeagueName(player.League.ID),
+			),
+		},
+		{
+			Name:  "Anforderungen",
+			Value: b.String(),
+		},
+	}
+	for _, answer := range answers {
+		if answer.Answer == "" {
+			continue
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  answer.Question,
+			Value: answer.Answer,
+		})
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Bewerbung für %s", clanName),
+		fmt.Sprintf("%s hat sich mit [%s (%s)](%s) beworben.", applicant.Mention(), player.Name, player.Tag, player.InGameURL()),
+		color,
+		fields,
+	)
+}
+
+// ApplicationDecisionEmbed returns a copy of the application embed with the decision of the leader.
+func ApplicationDecisionEmbed(application *discordgo.MessageEmbed, decision string, color int) *discordgo.MessageEmbed {
+	embed := *application
+	embed.Color = color
+	embed.Fields = append(embed.Fields[:len(embed.Fields):len(embed.Fields)], &discordgo.MessageEmbedField{
+		Name:  "Entscheidung:",
+		Value: decision,
+	})
+	return &embed
+}
+
+func ClanRequirementsEmbed(clanName string, settings *models.ClanSettings) *discordgo.MessageEmbed {
+	return NewFieldEmbed(
+		fmt.Sprintf("Anforderungen von %s", clanName),
+		fmt.Sprintf("Bewerbungen werden in %s gesendet.", formatChannel(settings.ChannelID(models.ClanChannelRecruitment))),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{
+				Name:   "Rathaus",
+				Value:  formatRequirement(settings.MinTownHallLevel, fmt.Sprintf("Level %d", settings.MinTownHallLevel)),
+				Inline: true,
+			},
+			{
+				Name:   "Heldenlevel (Summe)",
+				Value:  formatRequirement(settings.MinHeroLevels, fmt.Sprintf("%d Level", settings.MinHeroLevels)),
+				Inline: true,
+			},
+			{
+				Name:   "Kriegssterne",
+				Value:  formatRequirement(settings.MinWarStars, fmt.Sprintf("%d Sterne", settings.MinWarStars)),
+				Inline: true,
+			},
+			{
+				Name:   "Liga",
+				Value:  formatRequirement(settings.MinLeagueID, types.HomeLeagueName(settings.MinLeagueID)),
+				Inline: true,
+			},
+		},
+	)
+}
+
+func formatRequirement(value int, formatted string) string {
+	if value <= 0 {
+		return "Keine"
+	}
+	return formatted
+}
+
+func formatChannel(channelID string) string {
+	if channelID == "" {
+		return "keinen Channel (nicht festgelegt)"
+	}
+	return util.MentionChannel(channelID)
+}
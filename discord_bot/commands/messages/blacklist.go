@@ -0,0 +1,73 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+func BlacklistEmbed(entries []*models.BlacklistEntry) *discordgo.MessageEmbed {
+	if len(entries) == 0 {
+		return NewEmbed(
+			"Blacklist",
+			"Es stehen keine Spieler auf der Blacklist.",
+			ColorAqua,
+		)
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, min(len(entries), maxEmbedFields))
+	for _, entry := range entries {
+		if len(fields) == maxEmbedFields {
+			break
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  blacklistEntryName(entry),
+			Value: blacklistEntryValue(entry),
+		})
+	}
+
+	return NewFieldEmbed(
+		"Blacklist",
+		fmt.Sprintf("%d Spieler stehen auf der Blacklist.", len(entries)),
+		ColorAqua,
+		fields,
+	)
+}
+
+// BlacklistedEmbed is sent when an action is refused because the player is on the blacklist.
+func BlacklistedEmbed(entry *models.BlacklistEntry) *discordgo.MessageEmbed {
+	return NewEmbed(
+		"Spieler auf der Blacklist",
+		fmt.Sprintf("%s steht auf der Blacklist und kann nicht hinzugefügt werden.\n\n%s", blacklistEntryName(entry), blacklistEntryValue(entry)),
+		ColorRed,
+	)
+}
+
+// BlacklistWarning returns a warning about the blacklist entry, which can be appended to a response.
+func BlacklistWarning(entry *models.BlacklistEntry) string {
+	return fmt.Sprintf("%s steht auf der Blacklist. Grund: %s", blacklistEntryName(entry), entry.Reason)
+}
+
+func blacklistEntryName(entry *models.BlacklistEntry) string {
+	if entry.Player != nil {
+		return fmt.Sprintf("%s (%s)", entry.Player.Name, entry.PlayerTag)
+	}
+	return entry.PlayerTag
+}
+
+func blacklistEntryValue(entry *models.BlacklistEntry) string {
+	expires := "nie"
+	if entry.ExpiresAt != nil {
+		expires = util.FormatDate(*entry.ExpiresAt)
+	}
+
+	return fmt.Sprintf(
+		"%s\n*Läuft ab: %s | Hinzugefügt %s*",
+		entry.Reason,
+		expires,
+		util.FormatFromAt(entry.CreatedByUser, entry.CreatedAt),
+	)
+}
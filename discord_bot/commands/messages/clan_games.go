@@ -0,0 +1,143 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+func ClanGamesStartEmbed(clanName string, games *models.ClanGames, minPoints int) *discordgo.MessageEmbed {
+	desc := fmt.Sprintf("Die Clan Spiele von %s haben begonnen und laufen bis %s.", clanName, util.FormatDateTime(games.EndTime))
+	if minPoints > 0 {
+		desc += fmt.Sprintf(" Jedes Mitglied muss mindestens **%s Punkte** erreichen.", util.FormatNumber(minPoints))
+	}
+	return NewEmbed("Clan Spiele gestartet", desc, ColorAqua)
+}
+
+// ClanGamesProgressEmbed shows the points every member has earned so far.
+func ClanGamesProgressEmbed(clanName string, games *models.ClanGames, members []*models.ClanGamesMember, minPoints int) *discordgo.MessageEmbed {
+	return NewFieldEmbed(
+		fmt.Sprintf("Clan Spiele von %s", clanName),
+		fmt.Sprintf("Zwischenstand, die Clan Spiele laufen bis %s.\n%s", util.FormatDateTime(games.EndTime), clanGamesTable(members)),
+		ColorAqua,
+		clanGamesFields(members, minPoints),
+	)
+}
+
+// ClanGamesResultEmbed shows the points every member has earned during the Clan Games.
+func ClanGamesResultEmbed(clanName string, members []*models.ClanGamesMember, minPoints int) *discordgo.MessageEmbed {
+	color := ColorGreen
+	if len(clanGamesBelowMinimum(members, minPoints)) > 0 {
+		color = ColorYellow
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Clan Spiele von %s beendet", clanName),
+		fmt.Sprintf("Endergebnis der Clan Spiele:\n%s", clanGamesTable(members)),
+		color,
+		clanGamesFields(members, minPoints),
+	)
+}
+
+// ClanGamesFlagEmbed notifies the leaders about the members who have not reached the minimum.
+func ClanGamesFlagEmbed(clanName string, members []*models.ClanGamesMember, minPoints int) *discordgo.MessageEmbed {
+	below := clanGamesBelowMinimum(members, minPoints)
+	lines := make([]string, len(below))
+	for i, member := range below {
+		lines[i] = fmt.Sprintf("**%s** (%s): %s Punkte", member.Name, member.PlayerTag, util.FormatNumber(member.Earned()))
+	}
+
+	return NewEmbed(
+		fmt.Sprintf("Clan Spiele Minimum nicht erreicht: %s", clanName),
+		fmt.Sprintf(
+			"Folgende Mitglieder haben weniger als %s Punkte erreicht. Kickpunkte können mit `/kpadd` vergeben werden.\n\n%s",
+			util.FormatNumber(minPoints),
+			strings.Join(lines, "\n"),
+		),
+		ColorYellow,
+	)
+}
+
+// clanGamesTable lists the earned points of the members. Members with a late start value are marked, as their points may be too low.
+func clanGamesTable(members []*models.ClanGamesMember) string {
+	var late bool
+	stats := make(types.PlayerStatistics, len(members))
+	for i, member := range members {
+		name := truncateName(member.Name)
+		if member.LateStart {
+			name += "*"
+			late = true
+		}
+		stats[i] = &types.PlayerStatistic{Tag: member.PlayerTag, Name: name, Value: member.Earned()}
+	}
+
+	table := PlayerLeaderboardTable(stats)
+	if late {
+		table += "\n\\* Startwert erst nach Beginn erfasst, die Punkte können zu niedrig sein. Diese Mitglieder werden nicht gemeldet."
+	}
+	return table
+}
+
+func clanGamesFields(members []*models.ClanGamesMember, minPoints int) []*discordgo.MessageEmbedField {
+	var total int
+	for _, member := range members {
+		total += member.Earned()
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Punkte gesamt", Value: util.FormatNumber(total), Inline: true},
+	}
+	if minPoints > 0 {
+		var checked int
+		for _, member := range members {
+			if !member.LateStart {
+				checked++
+			}
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Minimum erreicht",
+			Value:  fmt.Sprintf("%d/%d (%s Punkte)", checked-len(clanGamesBelowMinimum(members, minPoints)), checked, util.FormatNumber(minPoints)),
+			Inline: true,
+		})
+	}
+	return fields
+}
+
+// clanGamesBelowMinimum returns the members who have earned less than the minimum. A minimum of 0 flags nobody,
+// members with a late start value are never flagged.
+func clanGamesBelowMinimum(members []*models.ClanGamesMember, minPoints int) []*models.ClanGamesMember {
+	var below []*models.ClanGamesMember
+	for _, member := range members {
+		if !member.LateStart && member.Earned() < minPoints {
+			below = append(below, member)
+		}
+	}
+	return below
+}
+
+func ClanGamesSettingsEmbed(clanName string, settings *models.ClanSettings) *discordgo.MessageEmbed {
+	minimum := "Kein Minimum"
+	if settings.ClanGamesMinPoints > 0 {
+		minimum = fmt.Sprintf("%s Punkte", util.FormatNumber(settings.ClanGamesMinPoints))
+	}
+
+	channel := "Nicht festgelegt, siehe `/clanchannel`"
+	if channelID := settings.ChannelID(models.ClanChannelClanGames); channelID != "" {
+		channel = util.MentionChannel(channelID)
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Clan Spiele Einstellungen von %s", clanName),
+		"Start, Zwischenstände und das Endergebnis der Clan Spiele werden automatisch in den Channel gesendet. Mitglieder unter dem Minimum werden im Leader-Channel gemeldet, abwesende Mitglieder werden übersprungen.",
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Channel", Value: channel, Inline: true},
+			{Name: "Minimum", Value: minimum, Inline: true},
+		},
+	)
+}
@@ -2,10 +2,9 @@ package messages
 
 import (
 	"fmt"
-	"log/slog"
-	"math"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/aaantiii/goclash"
 	"github.com/alexeyco/simpletable"
@@ -46,132 +45,65 @@ func PlayerLeaderboardTable(playerStats types.PlayerStatistics) string {
 	return "```\n" + table.String() + "\n```"
 }
 
-type cwMember struct {
-	tag       string
-	name      string
-	discordID string
-	warPos    int
-}
-
-type cwDonator struct {
-	cwMember
-	donationRange cwDonatorRange
-}
-
-type cwDonatorRange struct {
-	start int
-	end   int
-}
-
-func getDonatorRanges(cwSize int) []cwDonatorRange {
-	numRanges := int(math.Ceil(float64(cwSize) / 10))
-	rangeSize := int(math.Ceil(float64(cwSize) / float64(numRanges)))
-
-	donatorRanges := make([]cwDonatorRange, numRanges)
-	for i := 0; i < numRanges; i++ {
-		start := i*rangeSize + 1
-		end := start + rangeSize - 1
-		if end > cwSize {
-			end = cwSize
-		}
-		donatorRanges[i] = cwDonatorRange{start: start, end: end}
+func CWDonatorEmbed(pingsDisabled bool) *discordgo.MessageEmbed {
+	desc := fmt.Sprintf("Folgende Mitglieder wurden als Spender ausgewählt. Wer in den letzten %d Tagen seltener eingeteilt wurde, hat eine höhere Chance:", util.CWDonorLookbackDays)
+	if !pingsDisabled {
+		return NewEmbed("CW Spender", desc, ColorAqua)
 	}
 
-	return donatorRanges
+	desc += "\n\n**Hinweis**: Du bist kein Vize-Anführer dieses Clans. Die Spender werden deshalb nicht gepingt, nicht gespeichert und können nicht neu ausgelost werden."
+	return NewEmbed("CW Spender", desc, ColorYellow)
 }
 
-func CWDonatorPing(members []*models.ClanMember, clanWarMembers []goclash.ClanWarMember, clanMemberByTag map[string]*goclash.Player) string {
-	memberByTag := make(map[string]*models.ClanMember, len(members))
-	for _, member := range members {
-		if member.Player == nil {
-			continue
-		}
-		memberByTag[member.PlayerTag] = member
+// CWDonatorPing lists the donor of every range, together with the reason the donor was chosen.
+func CWDonatorPing(donors []*types.CWDonor) string {
+	if len(donors) == 0 {
+		return "Es sind keine Mitglieder im Krieg."
 	}
 
-	var possibleDonators []cwMember
-	for _, clanWarMember := range clanWarMembers {
-		clanMember, found := clanMemberByTag[clanWarMember.Tag]
-		if !found {
-			slog.Error("clanMember not found", slog.String("tag", clanWarMember.Tag))
-			continue
-		}
-
-		if clanMember.WarPreference != "in" {
-			continue
-		}
-
-		discordID := ""
-		if member, memberExists := memberByTag[clanMember.Tag]; memberExists && member.Player != nil {
-			discordID = member.Player.DiscordID
-		}
-
-		possibleDonators = append(possibleDonators, cwMember{
-			tag:       clanWarMember.Tag,
-			name:      clanWarMember.Name,
-			discordID: discordID,
-			warPos:    clanWarMember.MapPosition,
-		})
+	content := ""
+	for _, donor := range donors {
+		content += CWDonatorLine(donor) + "\n"
 	}
+	return content
+}
 
-	// fmt.Println("Possible donators count:", len(possibleDonators))
-
-	if len(possibleDonators) == 0 {
-		return "Es sind keine Mitglieder im Krieg."
+// CWDonatorLine formats the donor of a single range. Each line starts with the range, so it can be replaced on a re-roll.
+func CWDonatorLine(donor *types.CWDonor) string {
+	line := cwDonatorLinePrefix(donor.Range) + " " + donor.Name
+	if donor.DiscordID != "" {
+		line += fmt.Sprintf(" (<@%s>)", donor.DiscordID)
 	}
+	return line + fmt.Sprintf(" (Nr. %d) · _%s_", donor.MapPosition, cwDonorReason(donor))
+}
 
-	sort.SliceStable(possibleDonators, func(i, j int) bool {
-		return possibleDonators[i].warPos < possibleDonators[j].warPos
-	})
-
-	cwSize := len(clanWarMembers)
-	ranges := getDonatorRanges(cwSize)
-	donators := make([]cwDonator, len(ranges))
-
-	uniqueRand := util.NewUniqueRand()
-	for index := range donators {
-		donationRange := ranges[index]
-		
-		// Find eligible donators (those NOT in the current donation range)
-		var eligibleDonators []cwMember
-		for _, donator := range possibleDonators {
-			// Skip if this player is in the range they would be donating to (can't donate to yourself)
-			if donator.warPos >= donationRange.start && donator.warPos <= donationRange.end {
-				continue
-			}
-			eligibleDonators = append(eligibleDonators, donator)
-		}
-		
-		// If no eligible donators, fall back to allowing self-donation as last resort
-		if len(eligibleDonators) == 0 {
-			eligibleDonators = possibleDonators
-		}
-		
-		// Select random donator from eligible ones
-		randomIndex := uniqueRand.Intn(0, len(eligibleDonators)-1)
-		selectedDonator := eligibleDonators[randomIndex]
-		
-		donators[index] = cwDonator{
-			cwMember:      selectedDonator,
-			donationRange: donationRange,
+// ReplaceCWDonatorLine replaces the line of the donor's range in a message created by CWDonatorPing.
+func ReplaceCWDonatorLine(content string, donor *types.CWDonor) string {
+	prefix := cwDonatorLinePrefix(donor.Range)
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, prefix) {
+			lines[i] = CWDonatorLine(donor)
 		}
 	}
+	return strings.Join(lines, "\n")
+}
 
-	// sort by donation range
-	sort.Slice(donators, func(i, j int) bool {
-		return donators[i].donationRange.start < donators[j].donationRange.start
-	})
+func cwDonatorLinePrefix(donorRange types.CWDonorRange) string {
+	return fmt.Sprintf("%d-%d:", donorRange.Start, donorRange.End)
+}
 
-	content := ""
-	for _, donator := range donators {
-		content += fmt.Sprintf("%d-%d: %s", donator.donationRange.start, donator.donationRange.end, donator.name)
-		if donator.discordID != "" {
-			content += fmt.Sprintf(" (<@%s>)", donator.discordID)
-		}
-		content += fmt.Sprintf(" (Nr. %d)\n", donator.warPos)
+func cwDonorReason(donor *types.CWDonor) string {
+	var reason string
+	switch {
+	case donor.OwnRange:
+		reason = "kein anderer Spender verfügbar"
+	case donor.RecentAssignments == 0 || donor.LastAssignedAt == nil:
+		reason = fmt.Sprintf("in den letzten %d Tagen nicht eingeteilt", util.CWDonorLookbackDays)
+	default:
+		reason = fmt.Sprintf("%dx in den letzten %d Tagen, zuletzt am %s", donor.RecentAssignments, util.CWDonorLookbackDays, util.FormatDate(*donor.LastAssignedAt))
 	}
-
-	return content
+	return fmt.Sprintf("%s, Chance %.0f%%", reason, donor.Chance*100)
 }
 
 type raidPingMember struct {
@@ -182,6 +114,25 @@ type raidPingMember struct {
 }
 
 func SendRaidPing(i *discordgo.InteractionCreate, members models.ClanMembers, raidSeason goclash.ClanCapitalRaidSeason) {
+	content := raidPingContent(members, raidSeason)
+	if content == "" {
+		SendEmbedResponse(i, NewEmbed("Alle Angriffe erledigt", "Es sind keine Angriffe mehr offen!", ColorGreen))
+		return
+	}
+
+	SendMessageResponse(i, "Fehlende Raid Angriffe", content)
+}
+
+// RaidReminder returns the message pinging the members with unused raid attacks, or an empty string if nobody has attacks left.
+func RaidReminder(members models.ClanMembers, raidSeason goclash.ClanCapitalRaidSeason) string {
+	content := raidPingContent(members, raidSeason)
+	if content == "" {
+		return ""
+	}
+	return fmt.Sprintf("## Fehlende Raid Angriffe\n%s", content)
+}
+
+func raidPingContent(members models.ClanMembers, raidSeason goclash.ClanCapitalRaidSeason) string {
 	raidMemberByTag := make(map[string]goclash.ClanCapitalRaidSeasonMember, len(raidSeason.Members))
 	for _, m := range raidSeason.Members {
 		raidMemberByTag[m.Tag] = m
@@ -223,12 +174,7 @@ func SendRaidPing(i *discordgo.InteractionCreate, members models.ClanMembers, ra
 		}
 	}
 
-	if content == "" {
-		SendEmbedResponse(i, NewEmbed("Alle Angriffe erledigt", "Es sind keine Angriffe mehr offen!", ColorGreen))
-		return
-	}
-
-	SendMessageResponse(i, "Fehlende Raid Angriffe", content)
+	return content
 }
 
 func EventEmbedFields(event *models.ClanEvent, playerStats types.PlayerStatistics) []*discordgo.MessageEmbedField {
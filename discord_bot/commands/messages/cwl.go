@@ -0,0 +1,292 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+func CWLSignupEmbed(season string) *discordgo.MessageEmbed {
+	return NewEmbed(
+		fmt.Sprintf("CWL Anmeldung %s", season),
+		"Klicke auf den Button, um deine verknüpften Accounts für die CWL an- oder abzumelden. Die Leader verteilen alle angemeldeten Accounts anschließend auf die Clans.",
+		ColorAqua,
+	)
+}
+
+// CWLSignupAccountsEmbed lists the accounts of a user, whether they are signed up and the preferred clan of the signed up accounts.
+func CWLSignupAccountsEmbed(season string, players models.Players, signedUp map[string]bool, preferredClanName string) *discordgo.MessageEmbed {
+	var desc strings.Builder
+	desc.WriteString("Klicke auf einen Account, um ihn an- oder abzumelden. Grün markierte Accounts sind angemeldet. ")
+	desc.WriteString("Unten kannst du auswählen, in welchem Clan deine angemeldeten Accounts bevorzugt eingeplant werden sollen.\n\n")
+	if preferredClanName != "" {
+		desc.WriteString(fmt.Sprintf("**Bevorzugter Clan:** %s\n\n", preferredClanName))
+	}
+	for _, player := range players {
+		status := "❌"
+		if signedUp[player.CocTag] {
+			status = "✅"
+		}
+		desc.WriteString(fmt.Sprintf("%s %s (%s)\n", status, player.Name, player.CocTag))
+	}
+
+	return NewEmbed(fmt.Sprintf("Deine CWL Anmeldungen %s", season), desc.String(), ColorAqua)
+}
+
+func CWLRostersEmbed(season string, rosters []*types.CWLRoster, unassigned []*models.CWLSignup, rosterSize int) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(rosters)+1)
+	for _, roster := range rosters {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s (%d/%d)", roster.Clan.Name, len(roster.Signups), rosterSize),
+			Value: cwlSignupList(roster.Signups, roster.Clan.Tag),
+		})
+	}
+	if len(unassigned) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("Ohne Platz (%d)", len(unassigned)),
+			Value: cwlSignupList(unassigned, ""),
+		})
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("CWL Aufstellungen %s", season),
+		"Accounts wurden nach Rathaus-Level verteilt und, wenn möglich, in ihrem bevorzugten Clan eingeplant (📌). Die vollständigen Aufstellungen befinden sich im Anhang.",
+		ColorAqua,
+		fields,
+	)
+}
+
+// CWLRostersFile exports the rosters as CSV file.
+func CWLRostersFile(season string, rosters []*types.CWLRoster, unassigned []*models.CWLSignup) (*discordgo.File, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"Clan", "Clan Tag", "Name", "Spieler Tag", "Rathaus", "Discord ID"}); err != nil {
+		return nil, err
+	}
+
+	write := func(clanName, clanTag string, signups []*models.CWLSignup) error {
+		for _, signup := range signups {
+			name, discordID := "", ""
+			if signup.Player != nil {
+				name, discordID = signup.Player.Name, signup.Player.DiscordID
+			}
+			if err := w.Write([]string{clanName, clanTag, name, signup.PlayerTag, strconv.Itoa(signup.TownHallLevel), discordID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, roster := range rosters {
+		if err := write(roster.Clan.Name, roster.Clan.Tag, roster.Signups); err != nil {
+			return nil, err
+		}
+	}
+	if err := write("Ohne Platz", "", unassigned); err != nil {
+		return nil, err
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return &discordgo.File{
+		Name:        fmt.Sprintf("cwl_%s.csv", season),
+		ContentType: "text/csv",
+		Reader:      &buf,
+	}, nil
+}
+
+// cwlSignupList lists the signups in an embed field. Accounts planned in their preferred clan are marked.
+func cwlSignupList(signups []*models.CWLSignup, clanTag string) string {
+	if len(signups) == 0 {
+		return "Keine Accounts"
+	}
+
+	var lines []string
+	for _, signup := range signups {
+		name := signup.PlayerTag
+		if signup.Player != nil {
+			name = signup.Player.Name
+		}
+		line := fmt.Sprintf("RH%d %s", signup.TownHallLevel, name)
+		if signup.PreferredClanTag != nil && *signup.PreferredClanTag == clanTag {
+			line += " 📌"
+		}
+		lines = append(lines, line)
+	}
+
+	return joinFieldLines(lines)
+}
+
+// joinFieldLines joins the lines of an embed field. Embed fields can hold at most 1024 characters, so lines
+// which don't fit anymore are cut off.
+func joinFieldLines(lines []string) string {
+	value := strings.Join(lines, "\n")
+	for len(value) > 1024 {
+		lines = lines[:len(lines)-1]
+		value = strings.Join(lines, "\n") + "\n…"
+	}
+	return value
+}
+
+// CWLGroupEmbed shows all clans of the CWL group with their town hall distribution, starting with the strongest roster.
+// The wars of the clan are listed with their results and the attacks of its members.
+func CWLGroupEmbed(group *goclash.ClanWarLeagueGroup, clanTag string, wars []*goclash.ClanWar) *discordgo.MessageEmbed {
+	type groupClan struct {
+		clan            goclash.ClanWarLeagueClan
+		counts          map[int]int
+		averageTownHall float64
+	}
+
+	clans := make([]groupClan, len(group.Clans))
+	for i, clan := range group.Clans {
+		counts := util.CWLTownHallCounts(clan)
+		var sum int
+		for level, count := range counts {
+			sum += level * count
+		}
+		clans[i] = groupClan{clan: clan, counts: counts}
+		if len(clan.Members) > 0 {
+			clans[i].averageTownHall = float64(sum) / float64(len(clan.Members))
+		}
+	}
+	sort.SliceStable(clans, func(i, j int) bool {
+		return clans[i].averageTownHall > clans[j].averageTownHall
+	})
+
+	fields := make([]*discordgo.MessageEmbedField, len(clans))
+	for i, c := range clans {
+		name := fmt.Sprintf("%d. %s", i+1, c.clan.Name)
+		if c.clan.Tag == clanTag {
+			name += " ⭐"
+		}
+
+		levels := make([]int, 0, len(c.counts))
+		for level := range c.counts {
+			levels = append(levels, level)
+		}
+		slices.Sort(levels)
+		slices.Reverse(levels)
+
+		distribution := make([]string, len(levels))
+		for j, level := range levels {
+			distribution[j] = fmt.Sprintf("RH%d: %d", level, c.counts[level])
+		}
+
+		fields[i] = &discordgo.MessageEmbedField{
+			Name:  name,
+			Value: fmt.Sprintf("Ø RH%.1f · %d Mitglieder\n%s", c.averageTownHall, len(c.clan.Members), strings.Join(distribution, " · ")),
+		}
+	}
+
+	if len(wars) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Runden",
+			Value: cwlRoundList(wars),
+		})
+	}
+	if stats := util.CWLMemberStatistics(wars); len(stats) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Angriffe",
+			Value: cwlMemberAttackList(stats),
+		})
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("CWL Gruppe %s", group.Season),
+		fmt.Sprintf("**Status**: %s\n**Runden ausgelost**: %d/%d\n\nClans nach durchschnittlichem Rathaus-Level der angemeldeten Mitglieder:", formatCWLGroupState(group.State), util.CWLDrawnRounds(group), len(group.Rounds)),
+		ColorAqua,
+		fields,
+	)
+}
+
+// cwlRoundList lists the stars and destruction of both clans in every round.
+func cwlRoundList(wars []*goclash.ClanWar) string {
+	lines := make([]string, len(wars))
+	for i, war := range wars {
+		var status string
+		switch {
+		case war.State == goclash.ClanWarStatePreparation:
+			status = "⏳"
+		case util.WarEnded(war):
+			status = warResultEmoji(util.WarResult(war))
+		default:
+			status = "⚔️"
+		}
+
+		lines[i] = fmt.Sprintf(
+			"%s Tag %d vs %s: ⭐ %d:%d · 💥 %.1f%% : %.1f%%",
+			status,
+			i+1,
+			war.Opponent.Name,
+			war.Clan.Stars,
+			war.Opponent.Stars,
+			war.Clan.DestructionPercentage,
+			war.Opponent.DestructionPercentage,
+		)
+	}
+	return joinFieldLines(lines)
+}
+
+// cwlMemberAttackList lists the used attacks, stars and destruction of every member who was in a lineup.
+func cwlMemberAttackList(stats []*types.CWLMemberStats) string {
+	lines := make([]string, len(stats))
+	for i, s := range stats {
+		lines[i] = fmt.Sprintf("RH%d %s: ⚔️ %d/%d · ⭐ %d · 💥 %d%%", s.TownHallLevel, s.Name, s.Attacks, s.Wars, s.Stars, s.Destruction)
+	}
+	return joinFieldLines(lines)
+}
+
+// CWLBonusEmbed shows the bonus ranking of the clan's CWL participants and suggests the recipients of the bonus medals.
+func CWLBonusEmbed(clanName, season string, ranking types.PlayerStatistics, bonuses int, weights types.CWLBonusWeights) *discordgo.MessageEmbed {
+	recipients := ranking[:min(bonuses, len(ranking))]
+	lines := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		lines[i] = fmt.Sprintf("%d. %s (%d Punkte)", i+1, recipient.Name, recipient.Value)
+	}
+	if len(ranking) > bonuses && ranking[bonuses].Value == ranking[bonuses-1].Value {
+		lines = append(lines, "\n⚠️ Auf dem letzten Platz herrscht Gleichstand, bitte entscheidet selbst.")
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("CWL Bonus %s – %s", season, clanName),
+		fmt.Sprintf(
+			"**Punkte**: ⭐ %d pro Stern · 💥 %d pro 100%% Zerstörung · ⚔️ %d pro Angriff · %d pro Rathaus-Level über dem eigenen (darunter Abzug)\n\n%s",
+			weights.Star,
+			weights.Destruction,
+			weights.Attack,
+			weights.TownHallDiff,
+			PlayerLeaderboardTable(ranking),
+		),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{{
+			Name:  fmt.Sprintf("Vorschlag für %d Boni", bonuses),
+			Value: joinFieldLines(lines),
+		}},
+	)
+}
+
+func formatCWLGroupState(state goclash.ClanWarLeagueGroupState) string {
+	switch state {
+	case goclash.ClanWarLeagueGroupStatePrep:
+		return "Vorbereitung"
+	case goclash.ClanWarLeagueGroupStateWar:
+		return "Krieg"
+	case goclash.ClanWarLeagueGroupStateEnded:
+		return "Beendet"
+	default:
+		return "Nicht in der CWL"
+	}
+}
@@ -0,0 +1,121 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+const (
+	maxDonationLeaderboardEntries = 25
+
+	// minFreeloaderReceived is the least amount of received donations to be flagged as freeloader,
+	// so that members who barely requested anything are not flagged.
+	minFreeloaderReceived = 100
+)
+
+// DonationsEmbed shows the donation leaderboard of the season and flags the members below the minimum ratio.
+func DonationsEmbed(clanName, season string, snapshots []*models.DonationSnapshot, minRatio float64) *discordgo.MessageEmbed {
+	title := fmt.Sprintf("Spenden von %s in der Season %s", clanName, season)
+	if len(snapshots) == 0 {
+		return NewEmbed(title, "Für diese Season wurden noch keine Spenden gespeichert.", ColorAqua)
+	}
+
+	table := simpletable.New()
+	table.Header = &simpletable.Header{
+		Cells: []*simpletable.Cell{
+			{Align: simpletable.AlignCenter, Text: "#"},
+			{Align: simpletable.AlignCenter, Text: "Name"},
+			{Align: simpletable.AlignCenter, Text: "Gesp."},
+			{Align: simpletable.AlignCenter, Text: "Erh."},
+			{Align: simpletable.AlignCenter, Text: "Verh."},
+		},
+	}
+
+	var donations, received int
+	for index, snapshot := range snapshots {
+		donations += snapshot.Donations
+		received += snapshot.DonationsReceived
+		if index >= maxDonationLeaderboardEntries {
+			continue
+		}
+
+		table.Body.Cells = append(table.Body.Cells, []*simpletable.Cell{
+			{Align: simpletable.AlignRight, Text: fmt.Sprintf("%d", index+1)},
+			{Align: simpletable.AlignLeft, Text: truncateName(snapshot.Name)},
+			{Align: simpletable.AlignRight, Text: util.FormatNumber(snapshot.Donations)},
+			{Align: simpletable.AlignRight, Text: util.FormatNumber(snapshot.DonationsReceived)},
+			{Align: simpletable.AlignRight, Text: fmt.Sprintf("%.2f", snapshot.Ratio())},
+		})
+	}
+	table.SetStyle(simpletable.StyleCompactLite)
+
+	desc := fmt.Sprintf("Stand: %s\n```\n%s\n```", util.FormatDateTime(latestDonationSnapshot(snapshots)), table.String())
+	if len(snapshots) > maxDonationLeaderboardEntries {
+		desc += fmt.Sprintf("\n... und %d weitere Mitglieder.", len(snapshots)-maxDonationLeaderboardEntries)
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Gespendet", Value: util.FormatNumber(donations), Inline: true},
+		{Name: "Erhalten", Value: util.FormatNumber(received), Inline: true},
+	}
+	if minRatio > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("Verhältnis unter %.2f", minRatio),
+			Value: formatFreeloaders(snapshots, minRatio),
+		})
+	}
+
+	return NewFieldEmbed(title, desc, ColorAqua, fields)
+}
+
+func formatFreeloaders(snapshots []*models.DonationSnapshot, minRatio float64) string {
+	var lines []string
+	for _, snapshot := range snapshots {
+		if snapshot.DonationsReceived < minFreeloaderReceived || snapshot.Ratio() >= minRatio {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(
+			"**%s**: %s/%s (%.2f)",
+			snapshot.Name,
+			util.FormatNumber(snapshot.Donations),
+			util.FormatNumber(snapshot.DonationsReceived),
+			snapshot.Ratio(),
+		))
+	}
+	if len(lines) == 0 {
+		return "Keine"
+	}
+	return strings.Join(lines, "\n")
+}
+
+func latestDonationSnapshot(snapshots []*models.DonationSnapshot) (latest time.Time) {
+	for _, snapshot := range snapshots {
+		if snapshot.UpdatedAt.After(latest) {
+			latest = snapshot.UpdatedAt
+		}
+	}
+	return latest
+}
+
+func DonationSettingsEmbed(clanName string, settings *models.ClanSettings) *discordgo.MessageEmbed {
+	minimum := "Kein Minimum"
+	if settings.MinDonationRatio > 0 {
+		minimum = fmt.Sprintf("%.2f", settings.MinDonationRatio)
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Spenden Einstellungen von %s", clanName),
+		fmt.Sprintf("Mitglieder, deren Verhältnis aus gespendeten und erhaltenen Truppen unter dem Minimum liegt, werden bei `/donations` aufgeführt, sobald sie mindestens %d Truppen erhalten haben.", minFreeloaderReceived),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Minimales Verhältnis", Value: minimum, Inline: true},
+		},
+	)
+}
@@ -0,0 +1,75 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+func LeftServerEmbed(user *discordgo.User, clanName string, members models.ClanMembers) *discordgo.MessageEmbed {
+	return NewEmbed(
+		"Mitglied hat den Server verlassen",
+		fmt.Sprintf(
+			"%s (%s) hat den Discord Server verlassen. Folgende Accounts sind noch Mitglied in %s:\n%s\nSollen die Mitgliedschaften entfernt werden?",
+			user.Mention(),
+			user.Username,
+			clanName,
+			formatMemberList(members),
+		),
+		ColorYellow,
+	)
+}
+
+func RejoinedServerEmbed(user *discordgo.User, clanName string, members models.ClanMembers) *discordgo.MessageEmbed {
+	return NewEmbed(
+		"Mitglied ist dem Server wieder beigetreten",
+		fmt.Sprintf(
+			"%s (%s) ist dem Discord Server wieder beigetreten. Folgende Accounts sind Mitglied in %s:\n%s\nSollen die Rollen wiederhergestellt werden?",
+			user.Mention(),
+			user.Username,
+			clanName,
+			formatMemberList(members),
+		),
+		ColorAqua,
+	)
+}
+
+func SendLeftServerPlayers(i *discordgo.InteractionCreate, clanName string, players models.Players) {
+	if len(players) == 0 {
+		SendEmbedResponse(i, NewEmbed(
+			"Keine Mitglieder gefunden",
+			fmt.Sprintf("Alle Mitglieder von %s sind auf dem Discord Server.", clanName),
+			ColorGreen,
+		))
+		return
+	}
+
+	var desc strings.Builder
+	for _, player := range players {
+		desc.WriteString(fmt.Sprintf(
+			"%s (%s) - %s, seit %s\n",
+			player.Name,
+			player.CocTag,
+			util.MentionUserID(player.DiscordID),
+			util.FormatDate(*player.LeftServerAt),
+		))
+	}
+
+	SendEmbedResponse(i, NewEmbed(
+		fmt.Sprintf("Mitglieder von %s, die den Server verlassen haben", clanName),
+		desc.String(),
+		ColorAqua,
+	))
+}
+
+func formatMemberList(members models.ClanMembers) string {
+	var list strings.Builder
+	for _, member := range members {
+		list.WriteString(fmt.Sprintf("- %s (%s), %s\n", member.Player.Name, member.PlayerTag, member.ClanRole.Format()))
+	}
+	return list.String()
+}
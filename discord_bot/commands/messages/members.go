@@ -12,7 +12,8 @@ import (
 	"bot/store/postgres/models"
 )
 
-func SendClanMembers(i *discordgo.InteractionCreate, clan *models.Clan) {
+// SendClanMembers sends all members of the clan grouped by role. Members with an active absence are listed separately as well.
+func SendClanMembers(i *discordgo.InteractionCreate, clan *models.Clan, absenceByTag map[string]*models.Absence) {
 	sort.SliceStable(clan.ClanMembers, func(i, j int) bool {
 		return strings.ToLower(clan.ClanMembers[i].Player.Name) < strings.ToLower(clan.ClanMembers[j].Player.Name)
 	})
@@ -31,6 +32,9 @@ func SendClanMembers(i *discordgo.InteractionCreate, clan *models.Clan) {
 		field.Value += " "
 		fields = append(fields, field)
 	}
+	if field := absentMembersField(clan.ClanMembers, absenceByTag); field != nil {
+		fields = append(fields, field)
+	}
 
 	SendEmbedResponse(i, NewFieldEmbed(
 		fmt.Sprintf("Mitglieder von %s", clan.Name),
@@ -0,0 +1,49 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+func NicknameReportEmbed(nicknames models.Nicknames) *discordgo.MessageEmbed {
+	if len(nicknames) == 0 {
+		return NewEmbed(
+			"Nickname Bericht",
+			"Alle Nicknames konnten erfolgreich aktualisiert werden.",
+			ColorGreen,
+		)
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, min(len(nicknames), maxEmbedFields))
+	for _, nickname := range nicknames {
+		if len(fields) == maxEmbedFields {
+			break
+		}
+
+		playerName := nickname.PlayerTag
+		if nickname.Player != nil {
+			playerName = nickname.Player.Name
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name: playerName,
+			Value: fmt.Sprintf(
+				"%s\nGewünschter Nickname: %s\nFehlgeschlagen seit: %s\nGrund: %s",
+				util.MentionUserID(nickname.DiscordID),
+				nickname.Build(playerName),
+				util.FormatDateTime(*nickname.FailedAt),
+				nickname.FailureReason,
+			),
+		})
+	}
+
+	return NewFieldEmbed(
+		"Nickname Bericht",
+		fmt.Sprintf("Bei %d Mitgliedern konnte der Nickname nicht aktualisiert werden.", len(nicknames)),
+		ColorYellow,
+		fields,
+	)
+}
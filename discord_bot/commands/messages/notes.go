@@ -0,0 +1,55 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+const maxEmbedFields = 25
+
+func NotesEmbed(playerName string, notes models.PlayerNotes) *discordgo.MessageEmbed {
+	if len(notes) == 0 {
+		return NewEmbed(
+			fmt.Sprintf("Notizen zu %s", playerName),
+			"Es gibt keine Notizen, die du sehen kannst.",
+			ColorAqua,
+		)
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, min(len(notes), maxEmbedFields))
+	for _, note := range notes {
+		if len(fields) == maxEmbedFields {
+			break
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("Notiz #%d", note.ID),
+			Value: noteFieldValue(note),
+		})
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Notizen zu %s", playerName),
+		fmt.Sprintf("%d Notizen gefunden.", len(notes)),
+		ColorAqua,
+		fields,
+	)
+}
+
+func noteFieldValue(note *models.PlayerNote) string {
+	clanName := note.ClanTag
+	if note.Clan != nil {
+		clanName = note.Clan.Name
+	}
+
+	return fmt.Sprintf(
+		"%s\n*%s | Sichtbar für: %s | %s*",
+		note.Content,
+		clanName,
+		note.Visibility.Format(),
+		util.FormatFromAt(note.CreatedByUser, note.CreatedAt),
+	)
+}
@@ -0,0 +1,91 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/types"
+)
+
+func ProfileEmbed(user *discordgo.User, profiles []*types.PlayerProfile) *discordgo.MessageEmbed {
+	title := fmt.Sprintf("Profil von %s", user.Username)
+	if len(profiles) == 0 {
+		return NewEmbed(
+			title,
+			fmt.Sprintf("%s hat keine verknüpften Accounts.", user.Mention()),
+			ColorRed,
+		)
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, min(len(profiles), maxEmbedFields))
+	for _, profile := range profiles {
+		if len(fields) == maxEmbedFields {
+			break
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s (%s)", profile.Player.Name, profile.Player.CocTag),
+			Value: profileFieldValue(profile),
+		})
+	}
+
+	return NewFieldEmbed(
+		title,
+		fmt.Sprintf("%s hat %d verknüpfte Accounts.", user.Mention(), len(profiles)),
+		ColorAqua,
+		fields,
+	)
+}
+
+func profileFieldValue(profile *types.PlayerProfile) string {
+	var b strings.Builder
+	if profile.ClashPlayer != nil {
+		b.WriteString(fmt.Sprintf("**Rathaus:** %d\n", profile.ClashPlayer.TownHallLevel))
+		b.WriteString(fmt.Sprintf("**Helden:** %s\n", formatHeroes(profile.ClashPlayer.Heroes)))
+	} else {
+		b.WriteString("*Live-Daten konnten nicht abgerufen werden.*\n")
+	}
+
+	clans := make([]string, 0, len(profile.Player.Members))
+	for _, member := range profile.Player.Members {
+		clanName := member.ClanTag
+		if member.Clan != nil {
+			clanName = member.Clan.Name
+		}
+
+		clan := fmt.Sprintf("%s (%s)", clanName, member.ClanRole.Format())
+		if slices.Contains(profile.LockedClanTags, member.ClanTag) {
+			clan += " 🔒"
+		}
+		clans = append(clans, clan)
+	}
+	if len(clans) == 0 {
+		clans = append(clans, "Kein Clan")
+	}
+
+	b.WriteString(fmt.Sprintf("**Clans:** %s\n", strings.Join(clans, ", ")))
+	b.WriteString(fmt.Sprintf("**Aktive Kickpunkte:** %d", profile.ActiveKickpoints))
+	if len(profile.LockedClanTags) > 0 {
+		b.WriteString("\n🔒 = Für Kickpunkte gesperrt")
+	}
+
+	return b.String()
+}
+
+func formatHeroes(heroes []goclash.PlayerItemLevel) string {
+	formatted := make([]string, 0, len(heroes))
+	for _, hero := range heroes {
+		if hero.Village != goclash.VillageHome {
+			continue
+		}
+		formatted = append(formatted, fmt.Sprintf("%s %d/%d", hero.Name, hero.Level, hero.MaxLevel))
+	}
+
+	if len(formatted) == 0 {
+		return "Keine"
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,93 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const maxPromotionCandidates = 10
+
+func PromotionsEmbed(clanName string, role models.ClanRole, candidates []*types.PromotionCandidate) *discordgo.MessageEmbed {
+	title := fmt.Sprintf("Beförderungen zum %s in %s", role.Format(), clanName)
+	if len(candidates) == 0 {
+		return NewEmbed(
+			title,
+			"Es gibt keine Mitglieder, die befördert werden können.",
+			ColorAqua,
+		)
+	}
+
+	var suggested int
+	fields := make([]*discordgo.MessageEmbedField, 0, min(len(candidates), maxPromotionCandidates))
+	for index, candidate := range candidates {
+		if candidate.Suggested() {
+			suggested++
+		}
+		if index == maxPromotionCandidates {
+			continue
+		}
+
+		name := fmt.Sprintf("%d. %s", index+1, candidate.Member.Player.Name)
+		if candidate.Suggested() {
+			name += " ⭐"
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  name,
+			Value: formatPromotionRequirements(candidate.Requirements),
+		})
+	}
+
+	return NewFieldEmbed(
+		title,
+		fmt.Sprintf("%d von %d Mitgliedern erfüllen alle Kriterien (⭐).", suggested, len(candidates)),
+		ColorAqua,
+		fields,
+	)
+}
+
+func formatPromotionRequirements(requirements []*types.Requirement) string {
+	parts := make([]string, len(requirements))
+	for i, requirement := range requirements {
+		icon := "✅"
+		if !requirement.Met {
+			icon = "❌"
+		}
+		parts[i] = fmt.Sprintf("%s %s: %s", icon, requirement.Name, requirement.Actual)
+	}
+	return strings.Join(parts, " | ")
+}
+
+func PromotionSettingsEmbed(clanName string, settings *models.ClanSettings) *discordgo.MessageEmbed {
+	return NewFieldEmbed(
+		fmt.Sprintf("Beförderungskriterien von %s", clanName),
+		"Mitglieder, die alle Kriterien erfüllen, werden bei `/promotions` vorgeschlagen.",
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{
+				Name:   "Mitgliedschaft",
+				Value:  fmt.Sprintf("mind. %d Tage", settings.PromotionMinDays),
+				Inline: true,
+			},
+			{
+				Name:   "Spenden (Season)",
+				Value:  fmt.Sprintf("mind. %d", settings.PromotionMinDonations),
+				Inline: true,
+			},
+			{
+				Name:   "Kriegsangriffe",
+				Value:  fmt.Sprintf("mind. %d%% der letzten Kriege", settings.PromotionMinAttackRate),
+				Inline: true,
+			},
+			{
+				Name:   "Kickpunkte (gesamt)",
+				Value:  fmt.Sprintf("max. %d", settings.PromotionMaxKickpoints),
+				Inline: true,
+			},
+		},
+	)
+}
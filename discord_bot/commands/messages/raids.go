@@ -0,0 +1,128 @@
+package messages
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+func RaidSettingsEmbed(clanName string, settings *models.ClanSettings) *discordgo.MessageEmbed {
+	reminders := "Deaktiviert"
+	if times := settings.RaidReminders(); len(times) > 0 {
+		values := make([]string, len(times))
+		for i, t := range times {
+			values[i] = t.String()
+		}
+		reminders = strings.Join(values, ", ")
+	}
+
+	channel := "Nicht festgelegt, siehe `/clanchannel`"
+	if channelID := settings.ChannelID(models.ClanChannelRaid); channelID != "" {
+		channel = util.MentionChannel(channelID)
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Raid Einstellungen von %s", clanName),
+		"Mitglieder mit offenen Raid Angriffen werden zu den angegebenen Zeiten (deutsche Zeit) im Raid-Channel gepingt. Abwesende Mitglieder werden übersprungen.",
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Channel", Value: channel, Inline: true},
+			{Name: "Erinnerungen", Value: reminders, Inline: true},
+		},
+	)
+}
+
+const (
+	maxRaidLeaderboardEntries = 15
+	maxRaidConsistencyEntries = 10
+	maxRaidWeekendEntries     = 10
+)
+
+// RaidStatsEmbed shows the capital gold leaderboard, the members with unused attacks and the clan results of the raid weekends.
+func RaidStatsEmbed(clanName string, weekends []*models.RaidWeekend, stats []*types.RaidPlayerStats) *discordgo.MessageEmbed {
+	if len(weekends) == 0 {
+		return NewEmbed(
+			fmt.Sprintf("Raid Statistiken von %s", clanName),
+			"Es wurden noch keine beendeten Raid Wochenenden gespeichert.",
+			ColorAqua,
+		)
+	}
+
+	leaderboard := make(types.PlayerStatistics, 0, min(len(stats), maxRaidLeaderboardEntries))
+	for _, s := range stats[:min(len(stats), maxRaidLeaderboardEntries)] {
+		leaderboard = append(leaderboard, &types.PlayerStatistic{Tag: s.PlayerTag, Name: truncateName(s.Name), Value: s.Looted})
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Raid Statistiken von %s", clanName),
+		fmt.Sprintf("Erbeutetes Clan Gold der letzten %d Raid Wochenenden:\n%s", len(weekends), PlayerLeaderboardTable(leaderboard)),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Offene Angriffe", Value: formatRaidConsistency(stats)},
+			{Name: "Clan Ergebnisse", Value: formatRaidWeekends(weekends)},
+		},
+	)
+}
+
+// formatRaidConsistency lists the members who did not use all of their attacks, including members who did not attack at all,
+// starting with the lowest share of used attacks. Complete weekends are counted against the weekends the player was a member for.
+func formatRaidConsistency(stats []*types.RaidPlayerStats) string {
+	incomplete := slices.DeleteFunc(slices.Clone(stats), func(s *types.RaidPlayerStats) bool {
+		return s.Attacks >= s.AttackLimit
+	})
+	if len(incomplete) == 0 {
+		return "Alle Mitglieder haben alle Angriffe genutzt."
+	}
+	slices.SortStableFunc(incomplete, func(a, b *types.RaidPlayerStats) int {
+		return cmp.Compare(a.AttackRate(), b.AttackRate())
+	})
+
+	lines := make([]string, 0, min(len(incomplete), maxRaidConsistencyEntries)+1)
+	for i, s := range incomplete {
+		if i == maxRaidConsistencyEntries {
+			lines = append(lines, fmt.Sprintf("*... und %d weitere*", len(incomplete)-maxRaidConsistencyEntries))
+			break
+		}
+		lines = append(lines, fmt.Sprintf(
+			"**%s**: %d/%d Angriffe (%.0f%%), %d/%d Wochenenden vollständig",
+			s.Name,
+			s.Attacks,
+			s.AttackLimit,
+			s.AttackRate()*100,
+			s.CompleteWeekends,
+			s.Weekends,
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatRaidWeekends(weekends []*models.RaidWeekend) string {
+	lines := make([]string, 0, min(len(weekends), maxRaidWeekendEntries)+1)
+	for i, weekend := range weekends {
+		if i == maxRaidWeekendEntries {
+			lines = append(lines, fmt.Sprintf("*... und %d weitere*", len(weekends)-maxRaidWeekendEntries))
+			break
+		}
+
+		var attacksPerRaid float64
+		if weekend.RaidsCompleted > 0 {
+			attacksPerRaid = float64(weekend.TotalAttacks) / float64(weekend.RaidsCompleted)
+		}
+		lines = append(lines, fmt.Sprintf(
+			"**%s**: 💰 %s · ⚔️ %d Angriffe · 🏰 %d Raids (Ø %.1f Angriffe)",
+			util.FormatDate(weekend.StartTime),
+			util.FormatNumber(weekend.CapitalTotalLoot),
+			weekend.TotalAttacks,
+			weekend.RaidsCompleted,
+			attacksPerRaid,
+		))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,66 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/types"
+)
+
+const (
+	maxRejoinRemovals   = 3
+	maxRejoinKickpoints = 3
+)
+
+// RejoinWarningEmbed asks for confirmation before a player with a rejoin history is added to a clan.
+func RejoinWarningEmbed(playerName string, history *types.RejoinHistory) *discordgo.MessageEmbed {
+	return NewEmbed(
+		"Ehemaliges Mitglied",
+		fmt.Sprintf("%s\n\nSoll der Spieler trotzdem hinzugefügt werden?", RejoinWarning(playerName, history)),
+		ColorYellow,
+	)
+}
+
+// RejoinWarning returns a warning block about the past removals, kickpoints and blacklist entry of the player, which can be appended to a description.
+func RejoinWarning(playerName string, history *types.RejoinHistory) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("⚠️ **%s war bereits in der Family:**", playerName))
+
+	if len(history.Removals) > 0 {
+		b.WriteString(fmt.Sprintf("\n**Entfernt (%dx):** ", len(history.Removals)))
+		removals := make([]string, 0, min(len(history.Removals), maxRejoinRemovals))
+		for _, entry := range history.Removals[:min(len(history.Removals), maxRejoinRemovals)] {
+			clanName := entry.ClanTag
+			if entry.Clan != nil {
+				clanName = entry.Clan.Name
+			}
+			removals = append(removals, fmt.Sprintf("%s am %s", clanName, util.FormatDate(*entry.LeftAt)))
+		}
+		b.WriteString(strings.Join(removals, ", "))
+	}
+
+	if history.KickpointSum > 0 {
+		b.WriteString(fmt.Sprintf("\n**Kickpunkte:** %d insgesamt, inklusive abgelaufener", history.KickpointSum))
+		for _, kickpoint := range history.Kickpoints[:min(len(history.Kickpoints), maxRejoinKickpoints)] {
+			clanName := kickpoint.ClanTag
+			if kickpoint.Clan != nil {
+				clanName = kickpoint.Clan.Name
+			}
+			b.WriteString(fmt.Sprintf("\n- %s, %s: %d - %s", util.FormatDate(kickpoint.Date), clanName, kickpoint.Amount, kickpoint.Description))
+		}
+	}
+
+	if entry := history.BlacklistEntry; entry != nil {
+		status := "aktiv"
+		if entry.ExpiresAt != nil && entry.ExpiresAt.Before(time.Now()) {
+			status = fmt.Sprintf("abgelaufen am %s", util.FormatDate(*entry.ExpiresAt))
+		}
+		b.WriteString(fmt.Sprintf("\n**Blacklist (%s):** %s", status, entry.Reason))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	maxScoutHistory    = 10
+	maxScoutKickpoints = 5
+)
+
+func ScoutEmbed(scout *types.PlayerScout) *discordgo.MessageEmbed {
+	player := scout.ClashPlayer
+	desc := fmt.Sprintf("[Im Spiel öffnen](%s)", player.InGameURL())
+	if player.Clan.Tag != "" {
+		desc += fmt.Sprintf("\nAktueller Clan: %s (%s)", player.Clan.Name, player.Clan.Tag)
+	}
+
+	color := ColorAqua
+	if scout.Rushed() {
+		color = ColorYellow
+		desc += "\n\n⚠️ **Gerusht:** Mindestens ein Held liegt unter dem Maximum des vorherigen Rathauses."
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Scout: %s (%s)", player.Name, player.Tag),
+		desc,
+		color,
+		[]*discordgo.MessageEmbedField{
+			{
+				Name: "Account",
+				Value: fmt.Sprintf(
+					"**Rathaus:** %d\n**Kriegssterne:** %s\n**Liga:** %s\n**Spenden:** %s / %s erhalten (%s)",
+					player.TownHallLevel,
+					util.FormatNumber(player.WarStars),
+					types.HomeLeagueName(player.League.ID),
+					util.FormatNumber(player.Donations),
+					util.FormatNumber(player.DonationsReceived),
+					formatDonationRatio(player.Donations, player.DonationsReceived),
+				),
+				Inline: true,
+			},
+			{
+				Name:   fmt.Sprintf("Helden (Maximum RH%d)", player.TownHallLevel),
+				Value:  formatScoutedHeroes(scout.Heroes),
+				Inline: true,
+			},
+			{
+				Name:  "Family-Historie",
+				Value: formatMemberHistory(scout.History),
+			},
+			{
+				Name:  "Kickpunkte",
+				Value: formatKickpointHistory(scout.Kickpoints),
+			},
+		},
+	)
+}
+
+func formatDonationRatio(donations, received int) string {
+	if received == 0 {
+		if donations == 0 {
+			return "keine Spenden"
+		}
+		return "∞"
+	}
+	return fmt.Sprintf("Verhältnis %.2f", float64(donations)/float64(received))
+}
+
+func formatScoutedHeroes(heroes []*types.ScoutedHero) string {
+	if len(heroes) == 0 {
+		return "Keine"
+	}
+
+	lines := make([]string, len(heroes))
+	for i, hero := range heroes {
+		lines[i] = fmt.Sprintf("%s: %d/%d", hero.Name, hero.Level, hero.MaxLevel)
+		if hero.Rushed() {
+			lines[i] += " ⚠️"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatMemberHistory(history []*models.MemberHistory) string {
+	if len(history) == 0 {
+		return "War noch nie in einem Family Clan."
+	}
+
+	lines := make([]string, 0, min(len(history), maxScoutHistory)+1)
+	for i, entry := range history {
+		if i == maxScoutHistory {
+			lines = append(lines, fmt.Sprintf("*... und %d weitere*", len(history)-maxScoutHistory))
+			break
+		}
+
+		clanName := entry.ClanTag
+		if entry.Clan != nil {
+			clanName = entry.Clan.Name
+		}
+
+		since := "?"
+		if entry.JoinedAt != nil {
+			since = util.FormatDate(*entry.JoinedAt)
+		}
+		until := "heute"
+		if entry.LeftAt != nil {
+			until = util.FormatDate(*entry.LeftAt)
+		}
+
+		line := fmt.Sprintf("**%s:** %s - %s", clanName, since, until)
+		if entry.ClanRole != "" {
+			line += fmt.Sprintf(" (%s)", entry.ClanRole.Format())
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatKickpointHistory(kickpoints []*models.Kickpoint) string {
+	if len(kickpoints) == 0 {
+		return "Keine Kickpunkte erhalten."
+	}
+
+	var total, active int
+	for _, kickpoint := range kickpoints {
+		total += kickpoint.Amount
+		if kickpoint.ExpiresAt.After(time.Now()) {
+			active += kickpoint.Amount
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("**Gesamt:** %d (davon %d aktiv)\n", total, active))
+	for i, kickpoint := range kickpoints {
+		if i == maxScoutKickpoints {
+			b.WriteString(fmt.Sprintf("*... und %d weitere*", len(kickpoints)-maxScoutKickpoints))
+			break
+		}
+
+		clanName := kickpoint.ClanTag
+		if kickpoint.Clan != nil {
+			clanName = kickpoint.Clan.Name
+		}
+		b.WriteString(fmt.Sprintf("%s, %s: %d - %s\n", util.FormatDate(kickpoint.Date), clanName, kickpoint.Amount, kickpoint.Description))
+	}
+	return b.String()
+}
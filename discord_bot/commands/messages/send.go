@@ -63,3 +63,99 @@ func SendChannelMessage(channelID, message string) {
 		slog.Error("Error sending message.", slog.Any("err", err))
 	}
 }
+
+func SendEphemeralEmbedResponse(i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	if err := util.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("Error responding to interaction.", slog.Any("err", err))
+	}
+}
+
+// SendEphemeralFollowup sends an embed only visible to the user of an interaction that has already been responded to.
+func SendEphemeralFollowup(i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	if _, err := util.Session.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	}); err != nil {
+		slog.Error("Error sending followup message.", slog.Any("err", err))
+	}
+}
+
+func SendComponentsResponse(i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) {
+	if err := util.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	}); err != nil {
+		slog.Error("Error responding to interaction.", slog.Any("err", err))
+	}
+}
+
+func SendEphemeralComponentsResponse(i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) {
+	if err := util.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("Error responding to interaction.", slog.Any("err", err))
+	}
+}
+
+func SendFileResponse(i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, file *discordgo.File) {
+	if err := util.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Files:  []*discordgo.File{file},
+		},
+	}); err != nil {
+		slog.Error("Error responding to interaction.", slog.Any("err", err))
+	}
+}
+
+// UpdateComponents replaces the message of a component interaction with the embed and components.
+func UpdateComponents(i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) {
+	if err := util.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	}); err != nil {
+		slog.Error("Error updating component message.", slog.Any("err", err))
+	}
+}
+
+// UpdateComponentMessage replaces the message of a component interaction with the embed and removes all components.
+func UpdateComponentMessage(i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	if err := util.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{},
+		},
+	}); err != nil {
+		slog.Error("Error updating component message.", slog.Any("err", err))
+	}
+}
+
+func SendChannelComponents(channelID string, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) error {
+	_, err := util.Session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	})
+	if err != nil {
+		slog.Error("Error sending message with components.", slog.Any("err", err))
+	}
+	return err
+}
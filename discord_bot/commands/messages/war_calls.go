@@ -0,0 +1,60 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+// WarBoardEmbed shows every enemy base with its best result so far and the member who called it.
+func WarBoardEmbed(war *goclash.ClanWar, calls []*models.WarCall) *discordgo.MessageEmbed {
+	callByTarget := make(map[int]*models.WarCall, len(calls))
+	for _, call := range calls {
+		callByTarget[call.TargetPosition] = call
+	}
+
+	var board strings.Builder
+	var threeStars, called int
+	for _, member := range sortedWarMembers(war.Opponent.Members) {
+		stars := 0
+		if member.BestOpponentAttack != nil {
+			stars = member.BestOpponentAttack.Stars
+		}
+
+		board.WriteString(fmt.Sprintf("`%2d.` RH%d %s · ", member.MapPosition, member.TownHallLevel, member.Name))
+		call, isCalled := callByTarget[member.MapPosition]
+		switch {
+		case stars == 3:
+			threeStars++
+			board.WriteString("✅ 3⭐")
+		case isCalled:
+			called++
+			board.WriteString(fmt.Sprintf("📌 %s (bis %s)", call.PlayerName, util.FormatDateTime(call.ExpiresAt)))
+			if stars > 0 {
+				board.WriteString(fmt.Sprintf(" · %d⭐", stars))
+			}
+		case stars > 0:
+			board.WriteString(fmt.Sprintf("%d⭐", stars))
+		default:
+			board.WriteString("offen")
+		}
+		board.WriteString("\n")
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Calls: %s vs %s", war.Clan.Name, war.Opponent.Name),
+		"Mit `/call` reservierst du eine Base, mit `/uncall` gibst du sie wieder frei.\n\n"+board.String(),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Gecallt", Value: fmt.Sprintf("%d/%d", called, war.TeamSize), Inline: true},
+			{Name: "3 Sterne", Value: fmt.Sprintf("%d/%d", threeStars, war.TeamSize), Inline: true},
+			{Name: "Aktualisiert", Value: util.FormatDateTime(time.Now()), Inline: true},
+		},
+	)
+}
@@ -0,0 +1,159 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/types"
+)
+
+// maxScoutMatchups is the number of dangerous matchups shown by WarScoutEmbed.
+const maxScoutMatchups = 5
+
+func WarScoutEmbed(war *goclash.ClanWar, scout *types.WarScout) *discordgo.MessageEmbed {
+	state := "Vorbereitung"
+	if war.State == goclash.ClanWarStateInWar {
+		state = "Kampftag"
+	}
+
+	description := fmt.Sprintf("%dvs%d Krieg, %s. Werte jeweils %s vs %s.", war.TeamSize, war.TeamSize, state, war.Clan.Name, war.Opponent.Name)
+	if missing := countWithoutLiveData(scout.Clan) + countWithoutLiveData(scout.Opponent); missing > 0 {
+		description += fmt.Sprintf("\n%d Spieler konnten nicht geladen werden und fehlen bei Helden, Kriegssternen und Matchups.", missing)
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Scouting: %s vs %s", war.Clan.Name, war.Opponent.Name),
+		description,
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Rathäuser", Value: formatTownHallComparison(war), Inline: true},
+			{Name: "Ø Heldenlevel", Value: formatHeroLevelComparison(scout), Inline: true},
+			{Name: "Kriegssterne", Value: formatWarStarComparison(scout), Inline: true},
+			{Name: "Gefährlichste Matchups", Value: formatDangerousMatchups(scout.Matchups)},
+		},
+	)
+}
+
+// formatHeroLevelComparison compares the average hero level sums of both lineups per town hall level.
+func formatHeroLevelComparison(scout *types.WarScout) string {
+	clanAverages := averageHeroLevels(scout.Clan)
+	opponentAverages := averageHeroLevels(scout.Opponent)
+
+	var levels []int
+	for level := range clanAverages {
+		levels = append(levels, level)
+	}
+	for level := range opponentAverages {
+		if _, ok := clanAverages[level]; !ok {
+			levels = append(levels, level)
+		}
+	}
+	slices.Sort(levels)
+	slices.Reverse(levels)
+
+	lines := make([]string, len(levels))
+	for i, level := range levels {
+		lines[i] = fmt.Sprintf("RH%d: %s vs %s", level, formatAverage(clanAverages, level), formatAverage(opponentAverages, level))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func averageHeroLevels(players []*types.WarScoutPlayer) map[int]float64 {
+	sums := make(map[int]int)
+	counts := make(map[int]int)
+	for _, player := range players {
+		if !player.LiveData {
+			continue
+		}
+		sums[player.TownHallLevel] += player.HeroLevels
+		counts[player.TownHallLevel]++
+	}
+
+	averages := make(map[int]float64, len(sums))
+	for level, sum := range sums {
+		averages[level] = float64(sum) / float64(counts[level])
+	}
+	return averages
+}
+
+func formatAverage(averages map[int]float64, level int) string {
+	average, ok := averages[level]
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", average)
+}
+
+// formatWarStarComparison compares the war stars of both lineups. Players without live data are left out.
+func formatWarStarComparison(scout *types.WarScout) string {
+	clanStars, clanCount := sumWarStars(scout.Clan)
+	opponentStars, opponentCount := sumWarStars(scout.Opponent)
+
+	var clanAverage, opponentAverage int
+	if clanCount > 0 {
+		clanAverage = clanStars / clanCount
+	}
+	if opponentCount > 0 {
+		opponentAverage = opponentStars / opponentCount
+	}
+
+	return fmt.Sprintf(
+		"Gesamt: %s vs %s\nØ: %s vs %s",
+		util.FormatNumber(clanStars),
+		util.FormatNumber(opponentStars),
+		util.FormatNumber(clanAverage),
+		util.FormatNumber(opponentAverage),
+	)
+}
+
+// sumWarStars returns the war stars of the players with live data and their count.
+func sumWarStars(players []*types.WarScoutPlayer) (sum, count int) {
+	for _, player := range players {
+		if !player.LiveData {
+			continue
+		}
+		sum += player.WarStars
+		count++
+	}
+	return sum, count
+}
+
+func countWithoutLiveData(players []*types.WarScoutPlayer) int {
+	var count int
+	for _, player := range players {
+		if !player.LiveData {
+			count++
+		}
+	}
+	return count
+}
+
+// formatDangerousMatchups lists the matchups in which the opponent is stronger than the member at the same position.
+func formatDangerousMatchups(matchups []*types.WarScoutMatchup) string {
+	var lines []string
+	for _, matchup := range matchups {
+		if len(lines) == maxScoutMatchups || matchup.Threat <= 0 {
+			break
+		}
+		lines = append(lines, fmt.Sprintf(
+			"**%d.** %s (RH%d, %d Helden) vs %s (RH%d, %d Helden)",
+			matchup.Member.MapPosition,
+			matchup.Member.Name,
+			matchup.Member.TownHallLevel,
+			matchup.Member.HeroLevels,
+			matchup.Opponent.Name,
+			matchup.Opponent.TownHallLevel,
+			matchup.Opponent.HeroLevels,
+		))
+	}
+
+	if len(lines) == 0 {
+		return "Kein Gegner ist stärker als das Mitglied auf seiner Position."
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,153 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const maxWarStatsNameLength = 12
+
+func WarStatsEmbed(clanName string, warCount int, stats []*types.WarPlayerStats) *discordgo.MessageEmbed {
+	if len(stats) == 0 {
+		return NewEmbed(
+			fmt.Sprintf("Kriegsstatistiken von %s", clanName),
+			"Es wurden noch keine beendeten Kriege gespeichert.",
+			ColorAqua,
+		)
+	}
+
+	table := simpletable.New()
+	table.Header = &simpletable.Header{
+		Cells: []*simpletable.Cell{
+			{Align: simpletable.AlignCenter, Text: "Name"},
+			{Align: simpletable.AlignCenter, Text: "CW"},
+			{Align: simpletable.AlignCenter, Text: "3⭐"},
+			{Align: simpletable.AlignCenter, Text: "Ø⭐"},
+			{Align: simpletable.AlignCenter, Text: "Fehlt"},
+		},
+	}
+	for _, s := range stats {
+		table.Body.Cells = append(table.Body.Cells, []*simpletable.Cell{
+			{Align: simpletable.AlignLeft, Text: truncateName(s.Name)},
+			{Align: simpletable.AlignRight, Text: fmt.Sprintf("%d", s.Wars)},
+			{Align: simpletable.AlignRight, Text: fmt.Sprintf("%.0f%%", s.HitRate()*100)},
+			{Align: simpletable.AlignRight, Text: fmt.Sprintf("%.2f", s.AverageStars())},
+			{Align: simpletable.AlignRight, Text: fmt.Sprintf("%d", s.MissedAttacks)},
+		})
+	}
+	table.SetStyle(simpletable.StyleCompactLite)
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Kriegsstatistiken von %s", clanName),
+		fmt.Sprintf("Die letzten %d Kriege. 3⭐ ist die Quote an Angriffen mit drei Sternen.\n```\n%s\n```", warCount, table.String()),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{{
+			Name:  "Ø Sterne nach RH-Differenz",
+			Value: formatTownHallDiffAverages(util.TownHallDiffAverages(stats)),
+		}},
+	)
+}
+
+func WarPlayerStatsEmbed(clanName string, warCount int, stats *types.WarPlayerStats) *discordgo.MessageEmbed {
+	return NewFieldEmbed(
+		fmt.Sprintf("Kriegsstatistiken von %s", stats.Name),
+		fmt.Sprintf("Die letzten %d Kriege von %s.", warCount, clanName),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Kriege", Value: fmt.Sprintf("%d", stats.Wars), Inline: true},
+			{Name: "Angriffe", Value: fmt.Sprintf("%d", stats.Attacks), Inline: true},
+			{Name: "Fehlende Angriffe", Value: fmt.Sprintf("%d", stats.MissedAttacks), Inline: true},
+			{Name: "3 Sterne", Value: fmt.Sprintf("%d (%.0f%%)", stats.ThreeStars, stats.HitRate()*100), Inline: true},
+			{Name: "Ø Sterne", Value: fmt.Sprintf("%.2f", stats.AverageStars()), Inline: true},
+			{Name: "Ø Sterne nach RH-Differenz", Value: formatTownHallDiffAverages(stats.ByTownHallDiff)},
+		},
+	)
+}
+
+func WarLogEmbed(clanName string, wars []*models.ClanWar) *discordgo.MessageEmbed {
+	if len(wars) == 0 {
+		return NewEmbed(
+			fmt.Sprintf("Kriegslog von %s", clanName),
+			"Es wurden noch keine beendeten Kriege gespeichert.",
+			ColorAqua,
+		)
+	}
+
+	resultCounts := make(map[models.WarResult]int)
+	lines := make([]string, len(wars))
+	for i, war := range wars {
+		resultCounts[war.Result]++
+		lines[i] = fmt.Sprintf(
+			"%s %s **%s** vs %s (%dvs%d)\n⭐ %d : %d | 💥 %.2f%% : %.2f%%",
+			warResultEmoji(war.Result),
+			util.FormatDate(war.EndTime),
+			war.Result.Format(),
+			war.OpponentName,
+			war.TeamSize,
+			war.TeamSize,
+			war.Stars,
+			war.OpponentStars,
+			war.Destruction,
+			war.OpponentDestruction,
+		)
+	}
+
+	return NewEmbed(
+		fmt.Sprintf("Kriegslog von %s", clanName),
+		fmt.Sprintf(
+			"Die letzten %d Kriege: %d Siege, %d Niederlagen, %d Unentschieden\n\n%s",
+			len(wars),
+			resultCounts[models.WarResultWin],
+			resultCounts[models.WarResultLose],
+			resultCounts[models.WarResultTie],
+			strings.Join(lines, "\n"),
+		),
+		ColorAqua,
+	)
+}
+
+func formatTownHallDiffAverages(averages map[int]*types.WarStarAverage) string {
+	if len(averages) == 0 {
+		return "Keine Angriffe"
+	}
+
+	diffs := make([]int, 0, len(averages))
+	for diff := range averages {
+		diffs = append(diffs, diff)
+	}
+	slices.Sort(diffs)
+
+	lines := make([]string, len(diffs))
+	for i, diff := range diffs {
+		average := averages[diff]
+		lines[i] = fmt.Sprintf("RH %+d: %.2f (%d Angriffe)", diff, average.Average(), average.Attacks)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func warResultEmoji(result models.WarResult) string {
+	switch result {
+	case models.WarResultWin:
+		return "✅"
+	case models.WarResultLose:
+		return "❌"
+	default:
+		return "➖"
+	}
+}
+
+func truncateName(name string) string {
+	runes := []rune(name)
+	if len(runes) <= maxWarStatsNameLength {
+		return name
+	}
+	return string(runes[:maxWarStatsNameLength-1]) + "…"
+}
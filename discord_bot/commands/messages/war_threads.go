@@ -0,0 +1,75 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+)
+
+// maxMessageLength is the maximum length of the content of a Discord message.
+const maxMessageLength = 2000
+
+func WarThreadName(war *goclash.ClanWar) string {
+	return fmt.Sprintf("CW gegen %s", war.Opponent.Name)
+}
+
+// WarAttackLog returns the attacks of both clans made after the attack with the given order, split into messages within the length limit of Discord.
+// The order of the last attack is returned as well.
+func WarAttackLog(war *goclash.ClanWar, afterOrder int) ([]string, int) {
+	memberByTag := make(map[string]goclash.ClanWarMember, len(war.Clan.Members)+len(war.Opponent.Members))
+	ownTags := make(map[string]bool, len(war.Clan.Members))
+	for _, member := range war.Clan.Members {
+		ownTags[member.Tag] = true
+	}
+
+	var attacks []goclash.ClanWarAttack
+	for _, members := range [][]goclash.ClanWarMember{war.Clan.Members, war.Opponent.Members} {
+		for _, member := range members {
+			memberByTag[member.Tag] = member
+			for _, attack := range member.Attacks {
+				if attack.Order > afterOrder {
+					attacks = append(attacks, attack)
+				}
+			}
+		}
+	}
+	slices.SortFunc(attacks, func(a, b goclash.ClanWarAttack) int {
+		return a.Order - b.Order
+	})
+
+	var log []string
+	var b strings.Builder
+	lastOrder := afterOrder
+	for _, attack := range attacks {
+		attacker, defender := memberByTag[attack.AttackerTag], memberByTag[attack.DefenderTag]
+
+		icon := "🛡️"
+		if ownTags[attack.AttackerTag] {
+			icon = "⚔️"
+		}
+
+		line := fmt.Sprintf(
+			"%s **%d.** %s → **%d.** %s: %s (%d%%)\n",
+			icon,
+			attacker.MapPosition,
+			attacker.Name,
+			defender.MapPosition,
+			defender.Name,
+			strings.Repeat("⭐", attack.Stars)+strings.Repeat("☆", 3-attack.Stars),
+			attack.DestructionPercentage,
+		)
+		if b.Len()+len(line) > maxMessageLength {
+			log = append(log, b.String())
+			b.Reset()
+		}
+		b.WriteString(line)
+		lastOrder = attack.Order
+	}
+	if b.Len() > 0 {
+		log = append(log, b.String())
+	}
+
+	return log, lastOrder
+}
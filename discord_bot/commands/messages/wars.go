@@ -0,0 +1,185 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+
+	"bot/commands/util"
+	"bot/store/postgres/models"
+)
+
+func WarLineupEmbed(war *goclash.ClanWar, startTime, endTime time.Time) *discordgo.MessageEmbed {
+	members := sortedWarMembers(war.Clan.Members)
+
+	var lineup strings.Builder
+	for _, member := range members {
+		lineup.WriteString(fmt.Sprintf("%d. %s (RH%d)\n", member.MapPosition, member.Name, member.TownHallLevel))
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Kriegsvorbereitung: %s vs %s", war.Clan.Name, war.Opponent.Name),
+		lineup.String(),
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Größe", Value: fmt.Sprintf("%dvs%d", war.TeamSize, war.TeamSize), Inline: true},
+			{Name: "Start", Value: util.FormatDateTime(startTime), Inline: true},
+			{Name: "Ende", Value: util.FormatDateTime(endTime), Inline: true},
+			{Name: "Rathäuser", Value: formatTownHallComparison(war), Inline: true},
+		},
+	)
+}
+
+// WarReminder returns the message pinging the members with unused attacks, or an empty string if nobody has attacks left.
+// Absent members are skipped, members without a linked Discord account are listed by name.
+func WarReminder(war *goclash.ClanWar, remaining time.Duration, memberByTag map[string]*models.ClanMember, absenceByTag map[string]*models.Absence) string {
+	var lines []string
+	for _, member := range sortedWarMembers(war.Clan.Members) {
+		if len(member.Attacks) >= util.WarAttacksPerMember {
+			continue
+		}
+		if _, ok := absenceByTag[member.Tag]; ok {
+			continue
+		}
+
+		name := fmt.Sprintf("**%s**", member.Name)
+		if clanMember, ok := memberByTag[member.Tag]; ok && clanMember.Player != nil && clanMember.Player.DiscordID != "" {
+			name = fmt.Sprintf("%s (%s)", util.MentionUserID(clanMember.Player.DiscordID), member.Name)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %d/%d", name, len(member.Attacks), util.WarAttacksPerMember))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"## Offene Kriegsangriffe\nDer Krieg gegen %s endet in %s. Folgende Mitglieder haben noch Angriffe offen:\n%s",
+		war.Opponent.Name,
+		util.FormatDuration(remaining.Round(time.Minute)),
+		strings.Join(lines, "\n"),
+	)
+}
+
+func WarResultEmbed(war *goclash.ClanWar) *discordgo.MessageEmbed {
+	result := util.WarResult(war)
+	color := ColorYellow
+	switch result {
+	case models.WarResultWin:
+		color = ColorGreen
+	case models.WarResultLose:
+		color = ColorRed
+	}
+
+	var missed []string
+	var attacks int
+	for _, member := range sortedWarMembers(war.Clan.Members) {
+		attacks += len(member.Attacks)
+		if len(member.Attacks) < util.WarAttacksPerMember {
+			missed = append(missed, fmt.Sprintf("%s (%d/%d)", member.Name, len(member.Attacks), util.WarAttacksPerMember))
+		}
+	}
+
+	missedValue := "Alle Angriffe wurden genutzt."
+	if len(war.Clan.Members) == 0 {
+		// wars finished from the war log have no lineup
+		missedValue = "Nicht verfügbar."
+	} else if len(missed) > 0 {
+		missedValue = strings.Join(missed, "\n")
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("%s: %s vs %s", result.Format(), war.Clan.Name, war.Opponent.Name),
+		fmt.Sprintf("Der %dvs%d Krieg ist beendet.", war.TeamSize, war.TeamSize),
+		color,
+		[]*discordgo.MessageEmbedField{
+			{Name: war.Clan.Name, Value: formatWarClanResult(war.Clan), Inline: true},
+			{Name: war.Opponent.Name, Value: formatWarClanResult(war.Opponent), Inline: true},
+			{Name: "Genutzte Angriffe", Value: fmt.Sprintf("%d/%d", attacks, war.TeamSize*util.WarAttacksPerMember)},
+			{Name: "Fehlende Angriffe", Value: missedValue},
+		},
+	)
+}
+
+func formatWarClanResult(clan goclash.WarClan) string {
+	return fmt.Sprintf("⭐ %d\n💥 %.2f%%", clan.Stars, clan.DestructionPercentage)
+}
+
+func formatTownHallComparison(war *goclash.ClanWar) string {
+	clanCounts := util.TownHallCounts(war.Clan)
+	opponentCounts := util.TownHallCounts(war.Opponent)
+
+	var levels []int
+	for level := range clanCounts {
+		levels = append(levels, level)
+	}
+	for level := range opponentCounts {
+		if _, ok := clanCounts[level]; !ok {
+			levels = append(levels, level)
+		}
+	}
+	slices.Sort(levels)
+	slices.Reverse(levels)
+
+	lines := make([]string, len(levels))
+	for i, level := range levels {
+		lines[i] = fmt.Sprintf("RH%d: %d vs %d", level, clanCounts[level], opponentCounts[level])
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sortedWarMembers(members []goclash.ClanWarMember) []goclash.ClanWarMember {
+	sorted := slices.Clone(members)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MapPosition < sorted[j].MapPosition
+	})
+	return sorted
+}
+
+func WarSettingsEmbed(clanName string, settings *models.ClanSettings) *discordgo.MessageEmbed {
+	reminders := "Deaktiviert"
+	if hours := settings.WarReminders(); len(hours) > 0 {
+		values := make([]string, len(hours))
+		for i, hour := range hours {
+			values[i] = fmt.Sprintf("%dh", hour)
+		}
+		reminders = strings.Join(values, ", ") + " vor Kriegsende"
+	}
+
+	channel := "Nicht festgelegt, siehe `/clanchannel`"
+	if channelID := settings.ChannelID(models.ClanChannelWar); channelID != "" {
+		channel = util.MentionChannel(channelID)
+	}
+
+	threads := "Deaktiviert"
+	if channelID := settings.ChannelID(models.ClanChannelWarThread); channelID != "" {
+		threads = util.MentionChannel(channelID)
+	}
+
+	calls := fmt.Sprintf("%d Minuten gültig, bis %d Rathaus-Level unter dem eigenen", settings.WarCallExpiryMinutes, settings.WarCallMaxTownHallsBelow)
+
+	donors := "Nur mit `/cwdonator`"
+	if settings.CWDonorAutoPost {
+		donors = "Automatisch zu Beginn der Vorbereitung"
+	}
+
+	return NewFieldEmbed(
+		fmt.Sprintf("Kriegseinstellungen von %s", clanName),
+		"Die Aufstellung, Erinnerungen an offene Angriffe und das Ergebnis werden automatisch in den Kriegs-Channel gesendet.",
+		ColorAqua,
+		[]*discordgo.MessageEmbedField{
+			{Name: "Channel", Value: channel, Inline: true},
+			{Name: "Threads", Value: threads, Inline: true},
+			{Name: "Erinnerungen", Value: reminders, Inline: true},
+			{Name: "Spender", Value: donors, Inline: true},
+			{Name: "Positionen pro Spender", Value: strconv.Itoa(settings.CWDonorRangeSize), Inline: true},
+			{Name: "Calls", Value: calls, Inline: true},
+		},
+	)
+}
@@ -187,6 +187,44 @@ func (m *AuthMiddleware) AuthorizeInteractionWithoutMessageEditNoClanNeeded(s *d
 	return errors.New("member is not a leader")
 }
 
+// ClanTagsWithRole returns the tags of all clans in which the user has at least the given role. Admins get the tags of all clans. Unlike AuthorizeInteraction, it does not send a response.
+func (m *AuthMiddleware) ClanTagsWithRole(i *discordgo.InteractionCreate, role types.AuthRole) ([]string, error) {
+	isAdmin, err := m.users.UserIsAdmin(i.Member.User.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	guilds, err := m.guilds.Guilds(i.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, guild := range guilds {
+		if isAdmin || guildRoleAtLeast(guild, i.Member.Roles, role) {
+			tags = append(tags, guild.ClanTag)
+		}
+	}
+
+	return tags, nil
+}
+
+// guildRoleAtLeast reports whether the roles contain the given role or any role above it.
+func guildRoleAtLeast(guild *models.Guild, roles []string, role types.AuthRole) bool {
+	switch role {
+	case types.AuthRoleMember:
+		return guild.IsMember(roles) || guild.IsElder(roles) || guild.IsCoLeader(roles) || guild.IsLeader(roles)
+	case types.AuthRoleElder:
+		return guild.IsElder(roles) || guild.IsCoLeader(roles) || guild.IsLeader(roles)
+	case types.AuthRoleCoLeader:
+		return guild.IsCoLeader(roles) || guild.IsLeader(roles)
+	case types.AuthRoleLeader:
+		return guild.IsLeader(roles)
+	default:
+		return false
+	}
+}
+
 func (m *AuthMiddleware) sendClanNotInGuildError(i *discordgo.InteractionCreate, clanTag string) {
 	messages.SendEmbedResponse(i, messages.NewEmbed(
 		"Ungültiger Clan",
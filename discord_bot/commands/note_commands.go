@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/handlers"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+func noteInteractionCommands(db *gorm.DB) types.Commands[types.InteractionHandler] {
+	handler := handlers.NewNoteHandler(
+		repos.NewNotesRepo(db),
+		repos.NewClansRepo(db),
+		repos.NewPlayersRepo(db),
+		repos.NewMembersRepo(db),
+		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
+	)
+
+	return types.Commands[types.InteractionHandler]{{
+		Handler: types.InteractionHandler{
+			Main:         handler.AddNote,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "noteadd",
+			Description:  "Fügt eine private Notiz zu einem Mitglied hinzu.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, in dem das Mitglied ist."),
+				optionMemberTag("Mitglied, zu dem die Notiz hinzugefügt werden soll."),
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        handlers.VisibilityOptionName,
+					Description: "Wer die Notiz sehen darf.",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: models.NoteVisibilityCoLeader.Format(), Value: models.NoteVisibilityCoLeader.String()},
+						{Name: models.NoteVisibilityFamily.Format(), Value: models.NoteVisibilityFamily.String()},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        handlers.NoteOptionName,
+					Description: "Inhalt der Notiz.",
+					Required:    true,
+					MinLength:   util.IntPtr(3),
+					MaxLength:   200,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Notes,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "notes",
+			Description:  "Zeigt alle Notizen zu einem Spieler an, die du sehen darfst.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionPlayerTag("Spieler, dessen Notizen angezeigt werden sollen."),
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main: handler.RemoveNote,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "noteremove",
+			Description:  "Löscht eine Notiz.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.IDOptionName,
+					Description: "ID der Notiz, die gelöscht werden soll.",
+					Required:    true,
+					MinValue:    util.FloatPtr(1),
+				},
+			},
+		},
+	}}
+}
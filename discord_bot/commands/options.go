@@ -43,3 +43,34 @@ func optionPlayerTag(desc string) *discordgo.ApplicationCommandOption {
 		MaxLength:    validation.TagMaxLength,
 	}
 }
+
+func optionWarCount() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionInteger,
+		Name:        handlers.WarsOptionName,
+		Description: "Anzahl der letzten Kriege, die berücksichtigt werden sollen (Standard: 10).",
+		MinValue:    util.FloatPtr(1),
+		MaxValue:    25,
+	}
+}
+
+func optionWarTarget(desc string) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionInteger,
+		Name:        handlers.TargetOptionName,
+		Description: desc,
+		Required:    true,
+		MinValue:    util.FloatPtr(1),
+		MaxValue:    50,
+	}
+}
+
+func optionCWLSeason() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        handlers.SeasonOptionName,
+		Description: "Season im Format YYYY-MM (Standard: nächste CWL).",
+		MinLength:   util.IntPtr(7),
+		MaxLength:   7,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 
 	"bot/commands/handlers"
+	"bot/commands/middleware"
 	"bot/commands/repos"
 	"bot/commands/util"
 	"bot/commands/validation"
@@ -13,7 +14,17 @@ import (
 )
 
 func playerInteractionCommands(db *gorm.DB, client *goclash.Client) types.Commands[types.InteractionHandler] {
-	handler := handlers.NewPlayerHandler(repos.NewPlayersRepo(db), client)
+	handler := handlers.NewPlayerHandler(
+		repos.NewPlayersRepo(db),
+		repos.NewMembersRepo(db),
+		repos.NewGuildsRepo(db),
+		repos.NewKickpointsRepo(db),
+		repos.NewMemberStatesRepo(db),
+		repos.NewNicknamesRepo(db),
+		repos.NewBlacklistRepo(db),
+		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
+		client,
+	)
 
 	return types.Commands[types.InteractionHandler]{{
 		Handler: types.InteractionHandler{
@@ -102,6 +113,48 @@ func playerInteractionCommands(db *gorm.DB, client *goclash.Client) types.Comman
 				},
 			},
 		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main: handler.NicknameSync,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "nicksync",
+			Description:  "Legt fest, ob dein Nickname automatisch an deinen in-Game Namen angepasst wird.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        handlers.EnabledOptionName,
+					Description: "Ob dein Nickname automatisch aktualisiert werden soll.",
+					Required:    true,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main: handler.NicknameReport,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "nickreport",
+			Description:  "Zeigt alle Mitglieder an, deren Nickname nicht automatisch aktualisiert werden konnte.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Scout,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "scout",
+			Description:  "Zeigt Rathaus, Helden, Kriegssterne, Spenden und die Family-Historie eines Spielers an.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionPlayerTag("Spieler, der gescoutet werden soll."),
+			},
+		},
 	}, {
 		Handler: types.InteractionHandler{
 			Main: handler.CheckReactions,
@@ -144,5 +197,76 @@ func playerInteractionCommands(db *gorm.DB, client *goclash.Client) types.Comman
 				},
 			},
 		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Unverify,
+			Autocomplete: handler.HandleAutocomplete,
+			Component:    handler.UnverifyComponent,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "unverify",
+			Description:  "Hebt die Verknüpfung eines deiner COC-Accounts mit deinem Discord Account auf.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         handlers.MyPlayerTagOptionName,
+					Description:  "Spieler-Tag des Accounts, dessen Verknüpfung aufgehoben werden soll.",
+					Required:     true,
+					MinLength:    util.IntPtr(validation.TagMinLength),
+					MaxLength:    validation.TagMaxLength,
+					Autocomplete: true,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Relink,
+			Autocomplete: handler.HandleAutocomplete,
+			Component:    handler.RelinkComponent,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "relink",
+			Description:  "Verknüpft einen COC-Account mit einem anderen Discord Account.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionPlayerTag("Account, der verschoben werden soll."),
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        handlers.UserOptionName,
+					Description: "Benutzer, mit dem der Account verknüpft werden soll.",
+					Required:    true,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main: handler.Profile,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "profile",
+			Description:  "Zeigt alle verknüpften Accounts eines Benutzers an.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        handlers.UserOptionName,
+					Description: "Benutzer, dessen Profil angezeigt werden soll. Standardmäßig du selbst.",
+					Required:    false,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main: handler.UserProfile,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "Profil anzeigen",
+			Type:         discordgo.UserApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+		},
 	}}
 }
@@ -0,0 +1,58 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres/models"
+)
+
+type IAbsencesRepo interface {
+	ActiveAbsences(playerTags []string) ([]*models.Absence, error)
+	AbsencesByDiscordID(discordID string) ([]*models.Absence, error)
+	CreateAbsences(absences []*models.Absence) error
+	DeleteAbsence(id uint, discordID string) error
+}
+
+type AbsencesRepo struct {
+	db *gorm.DB
+}
+
+func NewAbsencesRepo(db *gorm.DB) IAbsencesRepo {
+	return &AbsencesRepo{db: db}
+}
+
+// ActiveAbsences returns the absences of the players which are running right now.
+func (repo *AbsencesRepo) ActiveAbsences(playerTags []string) ([]*models.Absence, error) {
+	var absences []*models.Absence
+	err := repo.db.
+		Order("ends_at DESC").
+		Find(&absences, "player_tag IN ? AND starts_at <= NOW() AND ends_at > NOW()", playerTags).Error
+	return absences, err
+}
+
+// AbsencesByDiscordID returns all current and upcoming absences of the accounts linked with the user.
+func (repo *AbsencesRepo) AbsencesByDiscordID(discordID string) ([]*models.Absence, error) {
+	var absences []*models.Absence
+	err := repo.db.
+		Preload(clause.Associations).
+		Joins("JOIN players ON players.coc_tag = absences.player_tag").
+		Order("absences.starts_at, absences.id").
+		Find(&absences, "players.discord_id = ? AND absences.ends_at > NOW()", discordID).Error
+	return absences, err
+}
+
+func (repo *AbsencesRepo) CreateAbsences(absences []*models.Absence) error {
+	return repo.db.Omit(clause.Associations).Create(absences).Error
+}
+
+// DeleteAbsence deletes the absence, if it belongs to an account linked with the user.
+func (repo *AbsencesRepo) DeleteAbsence(id uint, discordID string) error {
+	result := repo.db.
+		Where("id = ? AND player_tag IN (?)", id, repo.db.Model(&models.Player{}).Select("coc_tag").Where("discord_id = ?", discordID)).
+		Delete(&models.Absence{})
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
@@ -0,0 +1,62 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres/models"
+)
+
+type IBlacklistRepo interface {
+	BlacklistEntry(playerTag string) (*models.BlacklistEntry, error)
+	ActiveBlacklistEntry(playerTag string) (*models.BlacklistEntry, error)
+	ActiveBlacklistEntries() ([]*models.BlacklistEntry, error)
+	SaveBlacklistEntry(entry *models.BlacklistEntry) error
+	DeleteBlacklistEntry(playerTag string) error
+}
+
+type BlacklistRepo struct {
+	db *gorm.DB
+}
+
+func NewBlacklistRepo(db *gorm.DB) IBlacklistRepo {
+	return &BlacklistRepo{db: db}
+}
+
+// BlacklistEntry returns the entry of the player, even if it has expired.
+func (repo *BlacklistRepo) BlacklistEntry(playerTag string) (*models.BlacklistEntry, error) {
+	var entry *models.BlacklistEntry
+	err := repo.db.
+		Preload(clause.Associations).
+		First(&entry, "player_tag = ?", playerTag).Error
+	return entry, err
+}
+
+func (repo *BlacklistRepo) ActiveBlacklistEntry(playerTag string) (*models.BlacklistEntry, error) {
+	var entry *models.BlacklistEntry
+	err := repo.db.
+		Preload(clause.Associations).
+		First(&entry, "player_tag = ? AND (expires_at IS NULL OR expires_at > NOW())", playerTag).Error
+	return entry, err
+}
+
+func (repo *BlacklistRepo) ActiveBlacklistEntries() ([]*models.BlacklistEntry, error) {
+	var entries []*models.BlacklistEntry
+	err := repo.db.
+		Preload(clause.Associations).
+		Order("created_at DESC").
+		Find(&entries, "expires_at IS NULL OR expires_at > NOW()").Error
+	return entries, err
+}
+
+func (repo *BlacklistRepo) SaveBlacklistEntry(entry *models.BlacklistEntry) error {
+	return repo.db.Omit(clause.Associations).Save(entry).Error
+}
+
+func (repo *BlacklistRepo) DeleteBlacklistEntry(playerTag string) error {
+	result := repo.db.Delete(&models.BlacklistEntry{}, "player_tag = ?", playerTag)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
@@ -0,0 +1,48 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres/models"
+)
+
+type IClanGamesRepo interface {
+	LatestClanGames(clanTag string) (*models.ClanGames, error)
+	SaveClanGames(games *models.ClanGames) error
+}
+
+type ClanGamesRepo struct {
+	db *gorm.DB
+}
+
+func NewClanGamesRepo(db *gorm.DB) IClanGamesRepo {
+	return &ClanGamesRepo{db: db}
+}
+
+// LatestClanGames returns the most recent Clan Games of the clan together with their members.
+func (repo *ClanGamesRepo) LatestClanGames(clanTag string) (*models.ClanGames, error) {
+	var games *models.ClanGames
+	err := repo.db.
+		Preload("Members").
+		Order("start_time DESC").
+		First(&games, "clan_tag = ?", clanTag).Error
+	return games, err
+}
+
+// SaveClanGames saves the Clan Games and creates or updates their members.
+func (repo *ClanGamesRepo) SaveClanGames(games *models.ClanGames) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(games).Error; err != nil {
+			return err
+		}
+
+		for _, member := range games.Members {
+			member.ClanGamesID = games.ID
+		}
+		if len(games.Members) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(games.Members).Error
+	})
+}
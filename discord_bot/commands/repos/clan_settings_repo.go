@@ -1,6 +1,8 @@
 package repos
 
 import (
+	"fmt"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -10,6 +12,7 @@ import (
 type IClanSettingsRepo interface {
 	ClanSettings(clanTag string) (*models.ClanSettings, error)
 	ClanSettingsPreload(clanTag string) (*models.ClanSettings, error)
+	ClanSettingsWithChannel(channel models.ClanChannel) ([]*models.ClanSettings, error)
 	UpdateClanSettings(settings *models.ClanSettings) error
 }
 
@@ -36,6 +39,16 @@ func (repo *ClanSettingsRepo) ClanSettingsPreload(clanTag string) (*models.ClanS
 	return clanSettings, err
 }
 
+// ClanSettingsWithChannel returns the settings of all clans which have set a channel of the given type.
+func (repo *ClanSettingsRepo) ClanSettingsWithChannel(channel models.ClanChannel) ([]*models.ClanSettings, error) {
+	var settings []*models.ClanSettings
+	err := repo.db.
+		Preload("Clan").
+		Where(fmt.Sprintf("%s <> ''", channel.Column())).
+		Find(&settings).Error
+	return settings, err
+}
+
 func (repo *ClanSettingsRepo) UpdateClanSettings(settings *models.ClanSettings) error {
 	return repo.db.Save(settings).Error
 }
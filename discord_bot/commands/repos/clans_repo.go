@@ -11,6 +11,7 @@ import (
 
 type IClansRepo interface {
 	Clans(query string) (models.Clans, error)
+	AllClans() (models.Clans, error)
 	ClanByTag(tag string) (*models.Clan, error)
 	ClanByTagPreload(tag string) (*models.Clan, error)
 	ClanNameByTag(tag string) (string, error)
@@ -34,6 +35,12 @@ func (repo *ClansRepo) Clans(query string) (models.Clans, error) {
 	return clans, err
 }
 
+func (repo *ClansRepo) AllClans() (models.Clans, error) {
+	var clans models.Clans
+	err := repo.db.Order("index").Find(&clans).Error
+	return clans, err
+}
+
 func (repo *ClansRepo) ClanByTag(tag string) (*models.Clan, error) {
 	var clan *models.Clan
 	err := repo.db.
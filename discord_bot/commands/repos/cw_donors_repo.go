@@ -0,0 +1,78 @@
+package repos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres/models"
+)
+
+type ICWDonorsRepo interface {
+	DonorAssignmentsSince(clanTag string, since time.Time) ([]*models.CWDonorAssignment, error)
+	WarDonorAssignments(clanTag string, preparationStartTime time.Time) ([]*models.CWDonorAssignment, error)
+	ReplaceWarDonorAssignments(clanTag string, preparationStartTime time.Time, assignments []*models.CWDonorAssignment) error
+	SaveDonorAssignment(assignment *models.CWDonorAssignment) error
+	OptedOutPlayerTags(playerTags []string) ([]string, error)
+	SetDonorOptOut(playerTag string, optOut bool) error
+}
+
+type CWDonorsRepo struct {
+	db *gorm.DB
+}
+
+func NewCWDonorsRepo(db *gorm.DB) ICWDonorsRepo {
+	return &CWDonorsRepo{db: db}
+}
+
+func (repo *CWDonorsRepo) DonorAssignmentsSince(clanTag string, since time.Time) ([]*models.CWDonorAssignment, error) {
+	var assignments []*models.CWDonorAssignment
+	err := repo.db.
+		Order("assigned_at DESC").
+		Find(&assignments, "clan_tag = ? AND assigned_at >= ?", clanTag, since).Error
+	return assignments, err
+}
+
+func (repo *CWDonorsRepo) WarDonorAssignments(clanTag string, preparationStartTime time.Time) ([]*models.CWDonorAssignment, error) {
+	var assignments []*models.CWDonorAssignment
+	err := repo.db.
+		Order("range_start").
+		Find(&assignments, "clan_tag = ? AND preparation_start_time = ?", clanTag, preparationStartTime).Error
+	return assignments, err
+}
+
+// ReplaceWarDonorAssignments replaces all donor assignments of the war, so only the latest selection counts.
+func (repo *CWDonorsRepo) ReplaceWarDonorAssignments(clanTag string, preparationStartTime time.Time, assignments []*models.CWDonorAssignment) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.CWDonorAssignment{}, "clan_tag = ? AND preparation_start_time = ?", clanTag, preparationStartTime).Error; err != nil {
+			return err
+		}
+		if len(assignments) == 0 {
+			return nil
+		}
+		return tx.Create(assignments).Error
+	})
+}
+
+func (repo *CWDonorsRepo) SaveDonorAssignment(assignment *models.CWDonorAssignment) error {
+	return repo.db.Save(assignment).Error
+}
+
+func (repo *CWDonorsRepo) OptedOutPlayerTags(playerTags []string) ([]string, error) {
+	var tags []string
+	err := repo.db.
+		Model(&models.CWDonorOptOut{}).
+		Where("player_tag IN ?", playerTags).
+		Pluck("player_tag", &tags).Error
+	return tags, err
+}
+
+func (repo *CWDonorsRepo) SetDonorOptOut(playerTag string, optOut bool) error {
+	if !optOut {
+		return repo.db.Delete(&models.CWDonorOptOut{}, "player_tag = ?", playerTag).Error
+	}
+	return repo.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.CWDonorOptOut{PlayerTag: playerTag}).Error
+}
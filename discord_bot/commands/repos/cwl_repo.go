@@ -0,0 +1,74 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres/models"
+)
+
+type ICWLRepo interface {
+	CWLSignups(season string) ([]*models.CWLSignup, error)
+	CWLSignupsByDiscordID(season, discordID string) ([]*models.CWLSignup, error)
+	CreateCWLSignup(signup *models.CWLSignup) error
+	DeleteCWLSignup(season, playerTag string) error
+	UpdateCWLPreference(season, discordID string, clanTag *string) error
+	SaveCWLAssignments(signups []*models.CWLSignup) error
+}
+
+type CWLRepo struct {
+	db *gorm.DB
+}
+
+func NewCWLRepo(db *gorm.DB) ICWLRepo {
+	return &CWLRepo{db: db}
+}
+
+func (repo *CWLRepo) CWLSignups(season string) ([]*models.CWLSignup, error) {
+	var signups []*models.CWLSignup
+	err := repo.db.
+		Preload(clause.Associations).
+		Order("town_hall_level DESC, created_at").
+		Find(&signups, "season = ?", season).Error
+	return signups, err
+}
+
+func (repo *CWLRepo) CWLSignupsByDiscordID(season, discordID string) ([]*models.CWLSignup, error) {
+	var signups []*models.CWLSignup
+	err := repo.db.
+		Joins("JOIN players ON players.coc_tag = cwl_signups.player_tag").
+		Find(&signups, "cwl_signups.season = ? AND players.discord_id = ?", season, discordID).Error
+	return signups, err
+}
+
+func (repo *CWLRepo) CreateCWLSignup(signup *models.CWLSignup) error {
+	return repo.db.Omit(clause.Associations).Create(signup).Error
+}
+
+func (repo *CWLRepo) DeleteCWLSignup(season, playerTag string) error {
+	return repo.db.Delete(&models.CWLSignup{}, "season = ? AND player_tag = ?", season, playerTag).Error
+}
+
+// UpdateCWLPreference sets the preferred clan of all signups of the user's accounts in the season.
+func (repo *CWLRepo) UpdateCWLPreference(season, discordID string, clanTag *string) error {
+	return repo.db.
+		Model(&models.CWLSignup{}).
+		Where("season = ? AND player_tag IN (?)", season, repo.db.
+			Model(&models.Player{}).
+			Select("coc_tag").
+			Where("discord_id = ?", discordID),
+		).
+		Update("preferred_clan_tag", clanTag).Error
+}
+
+// SaveCWLAssignments saves the clans the signups were assigned to by the roster planning.
+func (repo *CWLRepo) SaveCWLAssignments(signups []*models.CWLSignup) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		for _, signup := range signups {
+			if err := tx.Model(signup).Update("assigned_clan_tag", signup.AssignedClanTag).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
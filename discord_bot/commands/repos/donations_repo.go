@@ -0,0 +1,38 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres/models"
+)
+
+type IDonationsRepo interface {
+	DonationSnapshots(clanTag, season string) ([]*models.DonationSnapshot, error)
+	SaveDonationSnapshots(snapshots []*models.DonationSnapshot) error
+}
+
+type DonationsRepo struct {
+	db *gorm.DB
+}
+
+func NewDonationsRepo(db *gorm.DB) IDonationsRepo {
+	return &DonationsRepo{db: db}
+}
+
+// DonationSnapshots returns the snapshots of the season taken while the members were in the clan, ordered by donations.
+func (repo *DonationsRepo) DonationSnapshots(clanTag, season string) ([]*models.DonationSnapshot, error) {
+	var snapshots []*models.DonationSnapshot
+	err := repo.db.
+		Order("donations DESC").
+		Find(&snapshots, "clan_tag = ? AND season = ?", clanTag, season).Error
+	return snapshots, err
+}
+
+// SaveDonationSnapshots creates the snapshots or replaces the existing ones of the same season.
+func (repo *DonationsRepo) SaveDonationSnapshots(snapshots []*models.DonationSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return repo.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(snapshots).Error
+}
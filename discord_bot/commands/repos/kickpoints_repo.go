@@ -16,6 +16,7 @@ type IKickpointsRepo interface {
 	ActiveMemberKickpointsSum(memberTag string) (int, error)
 	FutureMemberKickpoints(memberTag string) ([]*models.Kickpoint, error)
 	KickpointSum(memberTag string) (int, error)
+	KickpointHistory(memberTag string) ([]*models.Kickpoint, error)
 	CreateKickpoint(kickpoint *models.Kickpoint) error
 	UpdateKickpoint(kickpoint *models.Kickpoint) (*models.Kickpoint, error)
 	DeleteKickpoint(id uint) error
@@ -111,6 +112,16 @@ func (repo *KickpointsRepo) KickpointSum(memberTag string) (int, error) {
 	return v.Sum, nil
 }
 
+// KickpointHistory returns all kickpoints of the member, including expired ones, starting with the most recent.
+func (repo *KickpointsRepo) KickpointHistory(memberTag string) ([]*models.Kickpoint, error) {
+	var kickpoints []*models.Kickpoint
+	err := repo.db.
+		Preload("Clan").
+		Order("date DESC").
+		Find(&kickpoints, "player_tag = ?", memberTag).Error
+	return kickpoints, err
+}
+
 func (repo *KickpointsRepo) CreateKickpoint(kickpoint *models.Kickpoint) error {
 	return repo.db.Create(&kickpoint).Error
 }
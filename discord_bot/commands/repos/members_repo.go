@@ -1,6 +1,8 @@
 package repos
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -18,6 +20,9 @@ type IMembersRepo interface {
 	TransferMember(playerTag, fromClanTag, toClanTag string, newRole models.ClanRole, transferredByDiscordID string) error
 	UpdateMemberRole(playerTag, clanTag string, role models.ClanRole) error
 	DeleteMember(tag, clanTag string) error
+	MemberHistory(playerTag string) ([]*models.MemberHistory, error)
+	CurrentMemberHistory(clanTag string) ([]*models.MemberHistory, error)
+	MemberHistorySince(clanTag string, since time.Time) ([]*models.MemberHistory, error)
 }
 
 type MembersRepo struct {
@@ -95,7 +100,12 @@ func (repo *MembersRepo) MissingClanMembers(clanTag string, playerTags ...string
 }
 
 func (repo *MembersRepo) CreateMember(member *models.ClanMember) error {
-	return repo.db.Create(member).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		return createMemberHistory(tx, member.PlayerTag, member.ClanTag, member.ClanRole)
+	})
 }
 
 func (repo *MembersRepo) TransferMember(playerTag, fromClanTag, toClanTag string, newRole models.ClanRole, transferredByDiscordID string) error {
@@ -104,7 +114,10 @@ func (repo *MembersRepo) TransferMember(playerTag, fromClanTag, toClanTag string
 		if err := tx.Delete(&models.ClanMember{}, "player_tag = ? AND clan_tag = ?", playerTag, fromClanTag).Error; err != nil {
 			return err
 		}
-		
+		if err := closeMemberHistory(tx, playerTag, fromClanTag, models.LeftReasonTransferred); err != nil {
+			return err
+		}
+
 		// Add to new clan
 		newMember := &models.ClanMember{
 			PlayerTag:        playerTag,
@@ -112,17 +125,85 @@ func (repo *MembersRepo) TransferMember(playerTag, fromClanTag, toClanTag string
 			ClanRole:         newRole,
 			AddedByDiscordID: transferredByDiscordID,
 		}
-		
-		return tx.Create(newMember).Error
+
+		if err := tx.Create(newMember).Error; err != nil {
+			return err
+		}
+		return createMemberHistory(tx, playerTag, toClanTag, newRole)
 	})
 }
 
 func (repo *MembersRepo) UpdateMemberRole(playerTag, clanTag string, role models.ClanRole) error {
-	return repo.db.
-		Model(&models.ClanMember{PlayerTag: playerTag, ClanTag: clanTag}).
-		Update("clan_role", role).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&models.ClanMember{PlayerTag: playerTag, ClanTag: clanTag}).
+			Update("clan_role", role).Error; err != nil {
+			return err
+		}
+		return tx.
+			Model(&models.MemberHistory{}).
+			Where("player_tag = ? AND clan_tag = ? AND left_at IS NULL", playerTag, clanTag).
+			Update("clan_role", role).Error
+	})
 }
 
 func (repo *MembersRepo) DeleteMember(tag, clanTag string) error {
-	return repo.db.Delete(&models.ClanMember{}, "player_tag = ? AND clan_tag = ?", tag, clanTag).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ClanMember{}, "player_tag = ? AND clan_tag = ?", tag, clanTag).Error; err != nil {
+			return err
+		}
+		return closeMemberHistory(tx, tag, clanTag, models.LeftReasonRemoved)
+	})
+}
+
+func (repo *MembersRepo) MemberHistory(playerTag string) ([]*models.MemberHistory, error) {
+	var history []*models.MemberHistory
+	err := repo.db.
+		Preload("Clan").
+		Order("left_at DESC NULLS FIRST, joined_at DESC").
+		Find(&history, "player_tag = ?", playerTag).Error
+	return history, err
+}
+
+// CurrentMemberHistory returns the history entries of all current memberships in the clan.
+func (repo *MembersRepo) CurrentMemberHistory(clanTag string) ([]*models.MemberHistory, error) {
+	var history []*models.MemberHistory
+	err := repo.db.Find(&history, "clan_tag = ? AND left_at IS NULL", clanTag).Error
+	return history, err
+}
+
+// MemberHistorySince returns the history entries of all memberships in the clan which did not end before since.
+func (repo *MembersRepo) MemberHistorySince(clanTag string, since time.Time) ([]*models.MemberHistory, error) {
+	var history []*models.MemberHistory
+	err := repo.db.Find(&history, "clan_tag = ? AND (left_at IS NULL OR left_at >= ?)", clanTag, since).Error
+	return history, err
+}
+
+func createMemberHistory(tx *gorm.DB, playerTag, clanTag string, role models.ClanRole) error {
+	now := time.Now()
+	return tx.Create(&models.MemberHistory{
+		PlayerTag: playerTag,
+		ClanTag:   clanTag,
+		ClanRole:  role,
+		JoinedAt:  &now,
+	}).Error
+}
+
+// closeMemberHistory sets the leave date of the current membership. Memberships from before the history was recorded are added without a join date.
+func closeMemberHistory(tx *gorm.DB, playerTag, clanTag string, reason models.LeftReason) error {
+	now := time.Now()
+	result := tx.
+		Model(&models.MemberHistory{}).
+		Where("player_tag = ? AND clan_tag = ? AND left_at IS NULL", playerTag, clanTag).
+		Updates(map[string]any{"left_at": now, "left_reason": reason})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+
+	return tx.Create(&models.MemberHistory{
+		PlayerTag:  playerTag,
+		ClanTag:    clanTag,
+		LeftAt:     &now,
+		LeftReason: reason,
+	}).Error
 }
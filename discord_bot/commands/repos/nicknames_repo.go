@@ -0,0 +1,55 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+
+	"bot/store/postgres/models"
+)
+
+type INicknamesRepo interface {
+	NicknameByDiscordID(discordID string) (*models.Nickname, error)
+	SyncedNicknames() (models.Nicknames, error)
+	FailedNicknames() (models.Nicknames, error)
+	SaveNickname(nickname *models.Nickname) error
+	DeleteNickname(discordID string) error
+}
+
+type NicknamesRepo struct {
+	db *gorm.DB
+}
+
+func NewNicknamesRepo(db *gorm.DB) INicknamesRepo {
+	return &NicknamesRepo{db: db}
+}
+
+func (repo *NicknamesRepo) NicknameByDiscordID(discordID string) (*models.Nickname, error) {
+	var nickname *models.Nickname
+	err := repo.db.First(&nickname, "discord_id = ?", discordID).Error
+	return nickname, err
+}
+
+// SyncedNicknames returns all nicknames which are built from an account and have the sync enabled.
+func (repo *NicknamesRepo) SyncedNicknames() (models.Nicknames, error) {
+	var nicknames models.Nicknames
+	err := repo.db.
+		Preload("Player").
+		Find(&nicknames, "sync_disabled = false AND player_tag <> ''").Error
+	return nicknames, err
+}
+
+func (repo *NicknamesRepo) FailedNicknames() (models.Nicknames, error) {
+	var nicknames models.Nicknames
+	err := repo.db.
+		Preload("Player").
+		Order("failed_at").
+		Find(&nicknames, "sync_disabled = false AND failed_at IS NOT NULL").Error
+	return nicknames, err
+}
+
+func (repo *NicknamesRepo) SaveNickname(nickname *models.Nickname) error {
+	return repo.db.Omit("Player").Save(nickname).Error
+}
+
+func (repo *NicknamesRepo) DeleteNickname(discordID string) error {
+	return repo.db.Delete(&models.Nickname{}, "discord_id = ?", discordID).Error
+}
@@ -0,0 +1,46 @@
+package repos
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres/models"
+)
+
+type INotesRepo interface {
+	NoteByID(id uint) (*models.PlayerNote, error)
+	NotesByPlayerTag(playerTag string) (models.PlayerNotes, error)
+	CreateNote(note *models.PlayerNote) error
+	DeleteNote(id uint) error
+}
+
+type NotesRepo struct {
+	db *gorm.DB
+}
+
+func NewNotesRepo(db *gorm.DB) INotesRepo {
+	return &NotesRepo{db: db}
+}
+
+func (repo *NotesRepo) NoteByID(id uint) (*models.PlayerNote, error) {
+	var note *models.PlayerNote
+	err := repo.db.Preload(clause.Associations).First(&note, id).Error
+	return note, err
+}
+
+func (repo *NotesRepo) NotesByPlayerTag(playerTag string) (models.PlayerNotes, error) {
+	var notes models.PlayerNotes
+	err := repo.db.
+		Preload(clause.Associations).
+		Order("created_at DESC").
+		Find(&notes, "player_tag = ?", playerTag).Error
+	return notes, err
+}
+
+func (repo *NotesRepo) CreateNote(note *models.PlayerNote) error {
+	return repo.db.Create(note).Error
+}
+
+func (repo *NotesRepo) DeleteNote(id uint) error {
+	return repo.db.Delete(&models.PlayerNote{}, id).Error
+}
@@ -3,8 +3,10 @@ package repos
 import (
 	"sort"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"bot/store/postgres"
 	"bot/store/postgres/models"
@@ -18,8 +20,11 @@ type IPlayersRepo interface {
 	PlayerByTagAndDiscordID(tag, discordID string) (*models.Player, error)
 	CreateOrUpdatePlayer(player *models.Player) error
 	NameByTag(tag string) (string, error)
+	PlayersByTags(tags ...string) (models.Players, error)
 	MembersPlayersByClan(clanTag, query string) (models.Players, error)
 	MyPlayers(discordID string, query string) (models.Players, error)
+	LeftServerPlayersByClan(clanTag string) (models.Players, error)
+	UpdateLeftServerAt(discordID string, leftAt *time.Time) error
 }
 
 type PlayersRepo struct {
@@ -63,8 +68,12 @@ func (repo *PlayersRepo) PlayerByTagAndDiscordID(tag, discordID string) (*models
 }
 
 // CreateOrUpdatePlayer returns types.ErrNoChanges if player tag exists and discord id did not change.
+// Only the name and the Discord ID of an existing player are updated, so that e.g. LeftServerAt is kept.
 func (repo *PlayersRepo) CreateOrUpdatePlayer(player *models.Player) error {
-	return repo.db.Save(player).Error
+	return repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "coc_tag"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "discord_id"}),
+	}).Create(player).Error
 }
 
 func (repo *PlayersRepo) NameByTag(tag string) (string, error) {
@@ -76,6 +85,12 @@ func (repo *PlayersRepo) NameByTag(tag string) (string, error) {
 	return name, err
 }
 
+func (repo *PlayersRepo) PlayersByTags(tags ...string) (models.Players, error) {
+	var players models.Players
+	err := repo.db.Find(&players, "coc_tag IN ?", tags).Error
+	return players, err
+}
+
 func (repo *PlayersRepo) MembersPlayersByClan(clanTag, query string) (models.Players, error) {
 	var players models.Players
 	if err := repo.db.
@@ -108,3 +123,24 @@ func (repo *PlayersRepo) MyPlayers(discordID string, query string) (models.Playe
 		Find(&players).Error
 	return players, err
 }
+
+func (repo *PlayersRepo) LeftServerPlayersByClan(clanTag string) (models.Players, error) {
+	var players models.Players
+	err := repo.db.
+		Where("left_server_at IS NOT NULL").
+		Where("coc_tag IN (?)", repo.db.
+			Model(&models.ClanMember{}).
+			Select("player_tag").
+			Where("clan_tag = ?", clanTag),
+		).
+		Order("left_server_at").
+		Find(&players).Error
+	return players, err
+}
+
+func (repo *PlayersRepo) UpdateLeftServerAt(discordID string, leftAt *time.Time) error {
+	return repo.db.
+		Model(&models.Player{}).
+		Where("discord_id = ?", discordID).
+		Update("left_server_at", leftAt).Error
+}
@@ -0,0 +1,71 @@
+package repos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres"
+	"bot/store/postgres/models"
+)
+
+type IRaidsRepo interface {
+	RaidWeekendStartTimes(clanTag string) ([]time.Time, error)
+	RaidWeekends(clanTag string, limit int, preload ...string) ([]*models.RaidWeekend, error)
+	SaveRaidWeekend(weekend *models.RaidWeekend) error
+}
+
+type RaidsRepo struct {
+	db *gorm.DB
+}
+
+func NewRaidsRepo(db *gorm.DB) IRaidsRepo {
+	return &RaidsRepo{db: db}
+}
+
+// RaidWeekendStartTimes returns the start times of all stored raid weekends of the clan.
+func (repo *RaidsRepo) RaidWeekendStartTimes(clanTag string) ([]time.Time, error) {
+	var startTimes []time.Time
+	err := repo.db.
+		Model(&models.RaidWeekend{}).
+		Where("clan_tag = ?", clanTag).
+		Pluck("start_time", &startTimes).Error
+	return startTimes, err
+}
+
+// RaidWeekends returns the latest stored raid weekends of the clan, starting with the most recent.
+func (repo *RaidsRepo) RaidWeekends(clanTag string, limit int, preload ...string) ([]*models.RaidWeekend, error) {
+	var weekends []*models.RaidWeekend
+	err := repo.db.
+		Scopes(postgres.WithPreloading(preload...), postgres.WithLimit(limit)).
+		Order("start_time DESC").
+		Find(&weekends, "clan_tag = ?", clanTag).Error
+	return weekends, err
+}
+
+// SaveRaidWeekend saves the raid weekend together with its members and districts.
+func (repo *RaidsRepo) SaveRaidWeekend(weekend *models.RaidWeekend) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(weekend).Error; err != nil {
+			return err
+		}
+
+		for _, member := range weekend.Members {
+			member.RaidWeekendID = weekend.ID
+		}
+		for _, district := range weekend.Districts {
+			district.RaidWeekendID = weekend.ID
+		}
+
+		if len(weekend.Members) > 0 {
+			if err := tx.Create(weekend.Members).Error; err != nil {
+				return err
+			}
+		}
+		if len(weekend.Districts) > 0 {
+			return tx.Create(weekend.Districts).Error
+		}
+		return nil
+	})
+}
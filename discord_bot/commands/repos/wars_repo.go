@@ -0,0 +1,126 @@
+package repos
+
+import (
+	"time"
+
+	"github.com/aaantiii/goclash"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"bot/store/postgres"
+	"bot/store/postgres/models"
+)
+
+type IWarsRepo interface {
+	ClanWar(clanTag string, preparationStartTime time.Time) (*models.ClanWar, error)
+	UnfinishedWars(clanTag string, endedBefore time.Time) ([]*models.ClanWar, error)
+	FinishedWars(clanTag string, limit int, preload ...string) ([]*models.ClanWar, error)
+	SaveClanWar(war *models.ClanWar) error
+	SaveFinishedWar(war *models.ClanWar) error
+	UpdateWarBoardMessage(warID uint, oldMessageID, messageID string) (bool, error)
+	ActiveWarCalls(clanWarID uint) ([]*models.WarCall, error)
+	CreateWarCall(call *models.WarCall) error
+	DeleteWarCall(id uint) error
+}
+
+type WarsRepo struct {
+	db *gorm.DB
+}
+
+func NewWarsRepo(db *gorm.DB) IWarsRepo {
+	return &WarsRepo{db: db}
+}
+
+func (repo *WarsRepo) ClanWar(clanTag string, preparationStartTime time.Time) (*models.ClanWar, error) {
+	var war *models.ClanWar
+	err := repo.db.First(&war, "clan_tag = ? AND preparation_start_time = ?", clanTag, preparationStartTime).Error
+	return war, err
+}
+
+// UnfinishedWars returns the wars of the clan which ended before the given time, but were never seen in the ended state by the tracker.
+func (repo *WarsRepo) UnfinishedWars(clanTag string, endedBefore time.Time) ([]*models.ClanWar, error) {
+	var wars []*models.ClanWar
+	err := repo.db.Find(&wars, "clan_tag = ? AND end_time < ? AND state NOT IN ?", clanTag, endedBefore, []string{"warEnded", goclash.ClanWarStateEnded}).Error
+	return wars, err
+}
+
+// FinishedWars returns the latest finished wars of the clan, starting with the most recent.
+func (repo *WarsRepo) FinishedWars(clanTag string, limit int, preload ...string) ([]*models.ClanWar, error) {
+	var wars []*models.ClanWar
+	err := repo.db.
+		Scopes(postgres.WithPreloading(preload...), postgres.WithLimit(limit)).
+		Order("end_time DESC").
+		Find(&wars, "clan_tag = ? AND result <> ''", clanTag).Error
+	return wars, err
+}
+
+// SaveClanWar saves the war without its associations. The board message of an existing war is only set by UpdateWarBoardMessage.
+func (repo *WarsRepo) SaveClanWar(war *models.ClanWar) error {
+	return saveClanWar(repo.db, war)
+}
+
+// SaveFinishedWar saves the war together with its participants and attacks.
+func (repo *WarsRepo) SaveFinishedWar(war *models.ClanWar) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveClanWar(tx, war); err != nil {
+			return err
+		}
+
+		for _, participant := range war.Participants {
+			participant.ClanWarID = war.ID
+		}
+		for _, attack := range war.Attacks {
+			attack.ClanWarID = war.ID
+		}
+
+		if len(war.Participants) > 0 {
+			if err := tx.Create(war.Participants).Error; err != nil {
+				return err
+			}
+		}
+		if len(war.Attacks) > 0 {
+			return tx.Create(war.Attacks).Error
+		}
+		return nil
+	})
+}
+
+// ActiveWarCalls returns the unexpired calls of the war, ordered by target.
+func (repo *WarsRepo) ActiveWarCalls(clanWarID uint) ([]*models.WarCall, error) {
+	var calls []*models.WarCall
+	err := repo.db.
+		Order("target_position").
+		Find(&calls, "clan_war_id = ? AND expires_at > NOW()", clanWarID).Error
+	return calls, err
+}
+
+// CreateWarCall creates the call and removes expired calls on the same target, as well as other calls of the player.
+func (repo *WarsRepo) CreateWarCall(call *models.WarCall) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.WarCall{}, "clan_war_id = ? AND ((target_position = ? AND expires_at <= NOW()) OR player_tag = ?)", call.ClanWarID, call.TargetPosition, call.PlayerTag).Error; err != nil {
+			return err
+		}
+		return tx.Create(call).Error
+	})
+}
+
+func (repo *WarsRepo) DeleteWarCall(id uint) error {
+	return repo.db.Delete(&models.WarCall{}, id).Error
+}
+
+// UpdateWarBoardMessage sets the call board message of the war if it still is the old one. It reports whether the message was set,
+// so that only one board is kept when the tracker and a call post a new board at the same time.
+func (repo *WarsRepo) UpdateWarBoardMessage(warID uint, oldMessageID, messageID string) (bool, error) {
+	result := repo.db.
+		Model(&models.ClanWar{}).
+		Where("id = ? AND board_message_id = ?", warID, oldMessageID).
+		Update("board_message_id", messageID)
+	return result.RowsAffected > 0, result.Error
+}
+
+func saveClanWar(tx *gorm.DB, war *models.ClanWar) error {
+	if war.ID == 0 {
+		return tx.Omit(clause.Associations).Create(war).Error
+	}
+	return tx.Omit(clause.Associations, "board_message_id").Save(war).Error
+}
@@ -0,0 +1,27 @@
+package util
+
+import "time"
+
+// the Clan Games run from the 22nd to the 28th of every month, starting and ending at 08:00 UTC
+const (
+	clanGamesStartDay = 22
+	clanGamesEndDay   = 28
+	clanGamesHour     = 8
+)
+
+// ClanGamesWindow returns the start and the end of the Clan Games in the month of t.
+func ClanGamesWindow(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), clanGamesStartDay, clanGamesHour, 0, 0, 0, time.UTC)
+	end := time.Date(t.Year(), t.Month(), clanGamesEndDay, clanGamesHour, 0, 0, 0, time.UTC)
+	return start, end
+}
+
+// NextClanGamesStart returns the start of the first Clan Games after t.
+func NextClanGamesStart(t time.Time) time.Time {
+	start, _ := ClanGamesWindow(t)
+	if !t.Before(start) {
+		start, _ = ClanGamesWindow(start.AddDate(0, 1, 0))
+	}
+	return start
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClanGamesWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		t         time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "before the start",
+			t:         time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, time.March, 22, 8, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, time.March, 28, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "during the Clan Games",
+			t:         time.Date(2026, time.March, 25, 12, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, time.March, 22, 8, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, time.March, 28, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "after the end",
+			t:         time.Date(2026, time.March, 31, 23, 59, 0, 0, time.UTC),
+			wantStart: time.Date(2026, time.March, 22, 8, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, time.March, 28, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "month of UTC, not of the local time",
+			t:         time.Date(2026, time.April, 1, 1, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+			wantStart: time.Date(2026, time.March, 22, 8, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, time.March, 28, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "December",
+			t:         time.Date(2026, time.December, 31, 12, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, time.December, 22, 8, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, time.December, 28, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		start, end := ClanGamesWindow(tt.t)
+		if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+			t.Errorf("%s: ClanGamesWindow(%v) = %v, %v, want %v, %v", tt.name, tt.t, start, end, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestNextClanGamesStart(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "before the start",
+			t:    time.Date(2026, time.March, 22, 7, 59, 59, 0, time.UTC),
+			want: time.Date(2026, time.March, 22, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "at the start",
+			t:    time.Date(2026, time.March, 22, 8, 0, 0, 0, time.UTC),
+			want: time.Date(2026, time.April, 22, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "after the start",
+			t:    time.Date(2026, time.March, 30, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, time.April, 22, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "December rollover",
+			t:    time.Date(2026, time.December, 23, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2027, time.January, 22, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		if got := NextClanGamesStart(tt.t); !got.Equal(tt.want) {
+			t.Errorf("%s: NextClanGamesStart(%v) = %v, want %v", tt.name, tt.t, got, tt.want)
+		}
+	}
+}
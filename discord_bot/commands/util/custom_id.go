@@ -19,3 +19,17 @@ func ParseCustomID(customID string) (cmdName, userID, otherID string) {
 
 	return split[0], split[1], split[2]
 }
+
+// BuildComponentID builds the custom id of a message component. Unlike BuildCustomID it contains no uuid, because component ids are limited to 100 characters.
+func BuildComponentID(cmdName, userID, action string, values ...string) string {
+	return strings.Join(append([]string{cmdName, userID, action}, values...), "$")
+}
+
+func ParseComponentID(customID string) (cmdName, userID, action string, values []string) {
+	split := strings.Split(customID, "$")
+	if len(split) < 3 {
+		return "", "", "", nil
+	}
+
+	return split[0], split[1], split[2], split[3:]
+}
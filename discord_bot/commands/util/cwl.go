@@ -0,0 +1,197 @@
+package util
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/aaantiii/goclash"
+
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+const (
+	cwlSeasonFormat = "2006-01"
+
+	// cwlLastDay is the last day of a month on which the CWL of that month can still be running.
+	cwlLastDay = 10
+
+	// cwlUndrawnWarTag is the war tag of rounds which have not been drawn yet.
+	cwlUndrawnWarTag = "#0"
+)
+
+// CWLSeason returns the season (YYYY-MM) of the next or currently running CWL.
+func CWLSeason(t time.Time) string {
+	if t.Day() > cwlLastDay {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	}
+	return t.Format(cwlSeasonFormat)
+}
+
+// ValidCWLSeason reports whether the season has the format YYYY-MM.
+func ValidCWLSeason(season string) bool {
+	_, err := time.Parse(cwlSeasonFormat, season)
+	return err == nil
+}
+
+// PlanCWLRosters spreads the signups across the clans. Accounts are placed by town hall level, highest first.
+// Each account gets into its preferred clan if there is room, the others go to the clan with the most free places.
+// Signups which don't fit into any roster are returned separately.
+func PlanCWLRosters(signups []*models.CWLSignup, clans models.Clans, rosterSize int) ([]*types.CWLRoster, []*models.CWLSignup) {
+	sorted := slices.Clone(signups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TownHallLevel > sorted[j].TownHallLevel
+	})
+
+	rosters := make([]*types.CWLRoster, len(clans))
+	rosterByTag := make(map[string]*types.CWLRoster, len(clans))
+	for index := range clans {
+		rosters[index] = &types.CWLRoster{Clan: &clans[index]}
+		rosterByTag[clans[index].Tag] = rosters[index]
+	}
+
+	assign := func(signup *models.CWLSignup, roster *types.CWLRoster) {
+		clanTag := roster.Clan.Tag
+		signup.AssignedClanTag = &clanTag
+		roster.Signups = append(roster.Signups, signup)
+	}
+
+	var remaining []*models.CWLSignup
+	for _, signup := range sorted {
+		signup.AssignedClanTag = nil
+		if signup.PreferredClanTag != nil {
+			if roster, ok := rosterByTag[*signup.PreferredClanTag]; ok && len(roster.Signups) < rosterSize {
+				assign(signup, roster)
+				continue
+			}
+		}
+		remaining = append(remaining, signup)
+	}
+
+	// the remaining accounts go to the roster with the most free places, so that strong accounts are spread across the clans
+	var unassigned []*models.CWLSignup
+	for _, signup := range remaining {
+		var target *types.CWLRoster
+		for _, roster := range rosters {
+			if len(roster.Signups) < rosterSize && (target == nil || len(roster.Signups) < len(target.Signups)) {
+				target = roster
+			}
+		}
+		if target == nil {
+			unassigned = append(unassigned, signup)
+			continue
+		}
+		assign(signup, target)
+	}
+
+	for _, roster := range rosters {
+		sort.SliceStable(roster.Signups, func(i, j int) bool {
+			return roster.Signups[i].TownHallLevel > roster.Signups[j].TownHallLevel
+		})
+	}
+	return rosters, unassigned
+}
+
+// CWLTownHallCounts counts the town hall levels of the members of a clan in a CWL group.
+func CWLTownHallCounts(clan goclash.ClanWarLeagueClan) map[int]int {
+	counts := make(map[int]int)
+	for _, member := range clan.Members {
+		counts[member.TownHallLevel]++
+	}
+	return counts
+}
+
+// CWLDrawnRounds returns the number of rounds of the group whose wars have already been drawn.
+func CWLDrawnRounds(group *goclash.ClanWarLeagueGroup) int {
+	var drawn int
+	for _, round := range group.Rounds {
+		if CWLRoundDrawn(round) {
+			drawn++
+		}
+	}
+	return drawn
+}
+
+// CWLRoundDrawn reports whether the wars of the round have already been drawn.
+func CWLRoundDrawn(round goclash.ClanWarLeagueRound) bool {
+	return len(round.WarTags) > 0 && round.WarTags[0] != cwlUndrawnWarTag
+}
+
+// CWLWarOfClan returns the war from the view of the clan, so that the clan is always on the Clan side.
+// It returns nil if the clan does not take part in the war.
+func CWLWarOfClan(war *goclash.ClanWar, clanTag string) *goclash.ClanWar {
+	switch clanTag {
+	case war.Clan.Tag:
+		return war
+	case war.Opponent.Tag:
+		swapped := *war
+		swapped.Clan, swapped.Opponent = war.Opponent, war.Clan
+		return &swapped
+	default:
+		return nil
+	}
+}
+
+// CWLMemberStatistics sums up the attacks of the clan members over the CWL wars, sorted by stars.
+// Wars still in preparation are skipped, since nobody could attack yet.
+func CWLMemberStatistics(wars []*goclash.ClanWar) []*types.CWLMemberStats {
+	statsByTag := make(map[string]*types.CWLMemberStats)
+	var stats []*types.CWLMemberStats
+	for _, war := range wars {
+		if war.State == goclash.ClanWarStatePreparation {
+			continue
+		}
+
+		opponentTownHalls := make(map[string]int, len(war.Opponent.Members))
+		for _, member := range war.Opponent.Members {
+			opponentTownHalls[member.Tag] = member.TownHallLevel
+		}
+
+		for _, member := range war.Clan.Members {
+			s, ok := statsByTag[member.Tag]
+			if !ok {
+				s = &types.CWLMemberStats{PlayerTag: member.Tag}
+				statsByTag[member.Tag] = s
+				stats = append(stats, s)
+			}
+			s.Name = member.Name
+			s.TownHallLevel = member.TownHallLevel
+			s.Wars++
+
+			for _, attack := range member.Attacks {
+				s.Attacks++
+				s.Stars += attack.Stars
+				s.Destruction += attack.DestructionPercentage
+				if level, ok := opponentTownHalls[attack.DefenderTag]; ok {
+					s.TownHallDiff += level - member.TownHallLevel
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Stars != stats[j].Stars {
+			return stats[i].Stars > stats[j].Stars
+		}
+		return stats[i].Destruction > stats[j].Destruction
+	})
+	return stats
+}
+
+// CWLBonusRanking ranks the members who were in a lineup by their bonus score, highest first.
+func CWLBonusRanking(stats []*types.CWLMemberStats, weights types.CWLBonusWeights) types.PlayerStatistics {
+	ranking := make(types.PlayerStatistics, len(stats))
+	for i, s := range stats {
+		ranking[i] = &types.PlayerStatistic{
+			Tag:   s.PlayerTag,
+			Name:  s.Name,
+			Value: weights.Score(s),
+		}
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].Value > ranking[j].Value
+	})
+	return ranking
+}
@@ -15,6 +15,10 @@ func MentionRole(roleID string) string {
 	return "<@&" + roleID + ">"
 }
 
+func MentionChannel(channelID string) string {
+	return "<#" + channelID + ">"
+}
+
 func DeleteInteractionResponseWithTimeout(s *discordgo.Session, i *discordgo.Interaction, timeout time.Duration) error {
 	time.Sleep(timeout)
 	return s.InteractionResponseDelete(i)
@@ -0,0 +1,42 @@
+package util
+
+import "time"
+
+const (
+	donationSeasonFormat = "2006-01"
+
+	// the season and with it the donations reset on the last Monday of a month at 05:00 UTC
+	donationSeasonResetHour = 5
+)
+
+// DonationSeason returns the season at the time t, named after the month in which it ends.
+func DonationSeason(t time.Time) string {
+	t = t.UTC()
+	if !t.Before(donationSeasonEnd(t.Year(), t.Month())) {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return t.Format(donationSeasonFormat)
+}
+
+// ValidDonationSeason reports whether the season has the format YYYY-MM.
+func ValidDonationSeason(season string) bool {
+	_, err := time.Parse(donationSeasonFormat, season)
+	return err == nil
+}
+
+// NextDonationSeasonEnd returns the first season reset after t.
+func NextDonationSeasonEnd(t time.Time) time.Time {
+	t = t.UTC()
+	end := donationSeasonEnd(t.Year(), t.Month())
+	if !t.Before(end) {
+		end = donationSeasonEnd(t.Year(), t.Month()+1)
+	}
+	return end
+}
+
+// donationSeasonEnd returns the reset at the end of the season ending in the given month.
+func donationSeasonEnd(year int, month time.Month) time.Time {
+	lastDay := time.Date(year, month+1, 0, donationSeasonResetHour, 0, 0, 0, time.UTC)
+	daysSinceMonday := (int(lastDay.Weekday()) + 6) % 7
+	return lastDay.AddDate(0, 0, -daysSinceMonday)
+}
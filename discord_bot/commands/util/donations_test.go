@@ -0,0 +1,69 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDonationSeason(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{name: "middle of the month", t: time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC), want: "2026-03"},
+		{name: "before the reset", t: time.Date(2026, time.March, 30, 4, 59, 59, 0, time.UTC), want: "2026-03"},
+		{name: "at the reset", t: time.Date(2026, time.March, 30, 5, 0, 0, 0, time.UTC), want: "2026-04"},
+		{name: "after the reset in the same month", t: time.Date(2026, time.March, 31, 12, 0, 0, 0, time.UTC), want: "2026-04"},
+		{name: "reset on the last day of the month", t: time.Date(2026, time.August, 31, 4, 0, 0, 0, time.UTC), want: "2026-08"},
+		{name: "after a reset on the last day of the month", t: time.Date(2026, time.August, 31, 5, 0, 0, 0, time.UTC), want: "2026-09"},
+		{name: "December before the reset", t: time.Date(2026, time.December, 28, 4, 59, 0, 0, time.UTC), want: "2026-12"},
+		{name: "December rollover", t: time.Date(2026, time.December, 28, 5, 0, 0, 0, time.UTC), want: "2027-01"},
+		{name: "New Year's Eve", t: time.Date(2026, time.December, 31, 23, 0, 0, 0, time.UTC), want: "2027-01"},
+		{name: "reset in UTC, not in local time", t: time.Date(2026, time.March, 30, 6, 30, 0, 0, time.FixedZone("CEST", 2*60*60)), want: "2026-03"},
+	}
+
+	for _, tt := range tests {
+		if got := DonationSeason(tt.t); got != tt.want {
+			t.Errorf("%s: DonationSeason(%v) = %s, want %s", tt.name, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestDonationSeasonEnd(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		want  time.Time
+	}{
+		{year: 2026, month: time.January, want: time.Date(2026, time.January, 26, 5, 0, 0, 0, time.UTC)},
+		{year: 2026, month: time.March, want: time.Date(2026, time.March, 30, 5, 0, 0, 0, time.UTC)},
+		{year: 2026, month: time.August, want: time.Date(2026, time.August, 31, 5, 0, 0, 0, time.UTC)},
+		{year: 2026, month: time.December, want: time.Date(2026, time.December, 28, 5, 0, 0, 0, time.UTC)},
+		{year: 2028, month: time.February, want: time.Date(2028, time.February, 28, 5, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := donationSeasonEnd(tt.year, tt.month); !got.Equal(tt.want) {
+			t.Errorf("donationSeasonEnd(%d, %s) = %v, want %v", tt.year, tt.month, got, tt.want)
+		}
+	}
+}
+
+func TestNextDonationSeasonEnd(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "before the reset", t: time.Date(2026, time.August, 31, 4, 55, 0, 0, time.UTC), want: time.Date(2026, time.August, 31, 5, 0, 0, 0, time.UTC)},
+		{name: "at the reset", t: time.Date(2026, time.August, 31, 5, 0, 0, 0, time.UTC), want: time.Date(2026, time.September, 28, 5, 0, 0, 0, time.UTC)},
+		{name: "December rollover", t: time.Date(2026, time.December, 29, 0, 0, 0, 0, time.UTC), want: time.Date(2027, time.January, 25, 5, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := NextDonationSeasonEnd(tt.t); !got.Equal(tt.want) {
+			t.Errorf("%s: NextDonationSeasonEnd(%v) = %v, want %v", tt.name, tt.t, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package util
+
+import (
+	"math"
+	"math/rand"
+
+	"bot/types"
+)
+
+const (
+	// DefaultCWDonorRangeSize is used for clans outside the family, which have no settings.
+	DefaultCWDonorRangeSize = 10
+
+	// CWDonorLookbackDays is the period in which previous donor assignments lower the chance of a member.
+	CWDonorLookbackDays = 30
+)
+
+func getDonatorRanges(cwSize, rangeSize int) []types.CWDonorRange {
+	numRanges := int(math.Ceil(float64(cwSize) / float64(rangeSize)))
+	rangeSize = int(math.Ceil(float64(cwSize) / float64(numRanges)))
+
+	donatorRanges := make([]types.CWDonorRange, numRanges)
+	for i := 0; i < numRanges; i++ {
+		start := i*rangeSize + 1
+		end := start + rangeSize - 1
+		if end > cwSize {
+			end = cwSize
+		}
+		donatorRanges[i] = types.CWDonorRange{Start: start, End: end}
+	}
+
+	return donatorRanges
+}
+
+// SelectCWDonors selects a donor for every range of up to rangeSize map positions. Each candidate is selected at most once, unless there are more ranges than candidates.
+func SelectCWDonors(cwSize, rangeSize int, candidates []*types.CWDonorCandidate) []*types.CWDonor {
+	if rangeSize <= 0 {
+		rangeSize = DefaultCWDonorRangeSize
+	}
+
+	ranges := getDonatorRanges(cwSize, rangeSize)
+	donors := make([]*types.CWDonor, 0, len(ranges))
+	selected := make(map[string]bool, len(ranges))
+	for _, donorRange := range ranges {
+		donor := SelectCWDonor(donorRange, candidates, selected)
+		if donor == nil {
+			continue
+		}
+		selected[donor.Tag] = true
+		donors = append(donors, donor)
+	}
+	return donors
+}
+
+// SelectCWDonor selects a weighted random donor for the range. Members of the range itself and excluded candidates are only selected if nobody else is left.
+func SelectCWDonor(donorRange types.CWDonorRange, candidates []*types.CWDonorCandidate, excluded map[string]bool) *types.CWDonor {
+	var outside, available, outsideExcluded []*types.CWDonorCandidate
+	for _, candidate := range candidates {
+		inRange := donorRange.Contains(candidate.MapPosition)
+		switch {
+		case !inRange && !excluded[candidate.Tag]:
+			outside = append(outside, candidate)
+		case !excluded[candidate.Tag]:
+			available = append(available, candidate)
+		case !inRange:
+			outsideExcluded = append(outsideExcluded, candidate)
+		}
+	}
+
+	for _, pool := range [][]*types.CWDonorCandidate{outside, available, outsideExcluded, candidates} {
+		if len(pool) == 0 {
+			continue
+		}
+
+		candidate, chance := weightedCandidate(pool)
+		return &types.CWDonor{
+			CWDonorCandidate: candidate,
+			Range:            donorRange,
+			Chance:           chance,
+			OwnRange:         donorRange.Contains(candidate.MapPosition),
+		}
+	}
+	return nil
+}
+
+func weightedCandidate(pool []*types.CWDonorCandidate) (*types.CWDonorCandidate, float64) {
+	var total float64
+	for _, candidate := range pool {
+		total += candidate.Weight()
+	}
+
+	r := rand.Float64() * total
+	for _, candidate := range pool {
+		if r < candidate.Weight() {
+			return candidate, candidate.Weight() / total
+		}
+		r -= candidate.Weight()
+	}
+
+	last := pool[len(pool)-1]
+	return last, last.Weight() / total
+}
@@ -87,3 +87,40 @@ func ChannelOptionByName(name string, options []*discordgo.ApplicationCommandInt
 	}
 	return nil, fmt.Errorf("channel option %s not found", name)
 }
+
+func UserOptionByName(name string, data discordgo.ApplicationCommandInteractionData) *discordgo.User {
+	for _, o := range data.Options {
+		if o.Name != name {
+			continue
+		}
+
+		user := o.UserValue(nil)
+		if data.Resolved != nil {
+			if resolved, ok := data.Resolved.Users[user.ID]; ok {
+				return resolved
+			}
+		}
+		return user
+	}
+	return nil
+}
+
+func BoolOptionByName(name string, options []*discordgo.ApplicationCommandInteractionDataOption) *bool {
+	for _, o := range options {
+		if o.Name == name {
+			value := o.BoolValue()
+			return &value
+		}
+	}
+	return nil
+}
+
+func FloatOptionByName(name string, options []*discordgo.ApplicationCommandInteractionDataOption) *float64 {
+	for _, o := range options {
+		if o.Name == name {
+			value := o.FloatValue()
+			return &value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+// states of a raid weekend, goclash does not define them
+const (
+	RaidSeasonOngoing = "ongoing"
+	RaidSeasonEnded   = "ended"
+)
+
+// raidAttackLimit is the number of raid attacks of a member without the bonus attack.
+const raidAttackLimit = 5
+
+// NewRaidWeekend converts a finished raid season of the clan to a raid weekend with its members and attacked districts.
+func NewRaidWeekend(clanTag string, season goclash.ClanCapitalRaidSeason) (*models.RaidWeekend, error) {
+	startTime, err := ParseClashDate(season.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := ParseClashDate(season.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	weekend := &models.RaidWeekend{
+		ClanTag:                 clanTag,
+		StartTime:               startTime,
+		EndTime:                 endTime,
+		CapitalTotalLoot:        season.CapitalTotalLoot,
+		RaidsCompleted:          season.RaidsCompleted,
+		TotalAttacks:            season.TotalAttacks,
+		EnemyDistrictsDestroyed: season.EnemyDistrictsDestroyed,
+		OffensiveReward:         season.OffensiveReward,
+		DefensiveReward:         season.DefensiveReward,
+		Members:                 make([]*models.RaidMember, len(season.Members)),
+	}
+
+	for i, member := range season.Members {
+		weekend.Members[i] = &models.RaidMember{
+			PlayerTag:              member.Tag,
+			Name:                   member.Name,
+			Attacks:                member.Attacks,
+			AttackLimit:            member.AttackLimit + member.BonusAttackLimit,
+			CapitalResourcesLooted: member.CapitalResourcesLooted,
+		}
+	}
+
+	for _, raid := range season.AttackLog {
+		for _, district := range raid.Districts {
+			weekend.Districts = append(weekend.Districts, &models.RaidDistrict{
+				DefenderTag:       raid.Defender.Tag,
+				DefenderName:      raid.Defender.Name,
+				Name:              district.Name,
+				DistrictHallLevel: district.DistrictHallLevel,
+				Stars:             district.Stars,
+				Destruction:       district.DestructionPercent,
+				Attacks:           district.AttackCount,
+				Looted:            district.TotalLooted,
+			})
+		}
+	}
+
+	return weekend, nil
+}
+
+// RaidPlayerStatistics aggregates the raid results per player, sorted by the looted capital gold.
+// The weekends must be loaded with their members. Players who were in the clan during a whole weekend without attacking
+// count as having missed all attacks of that weekend, names holds the names of these players by tag.
+func RaidPlayerStatistics(weekends []*models.RaidWeekend, history []*models.MemberHistory, names map[string]string) []*types.RaidPlayerStats {
+	statsByTag := make(map[string]*types.RaidPlayerStats)
+	statsOf := func(playerTag, name string) *types.RaidPlayerStats {
+		stats, ok := statsByTag[playerTag]
+		if !ok {
+			stats = &types.RaidPlayerStats{PlayerTag: playerTag, Name: name}
+			statsByTag[playerTag] = stats
+		}
+		return stats
+	}
+
+	for _, weekend := range weekends {
+		counted := make(map[string]bool, len(weekend.Members))
+		for _, member := range weekend.Members {
+			counted[member.PlayerTag] = true
+			stats := statsOf(member.PlayerTag, member.Name)
+			stats.Weekends++
+			stats.Attacks += member.Attacks
+			stats.AttackLimit += member.AttackLimit
+			stats.Looted += member.CapitalResourcesLooted
+			if member.Attacks >= member.AttackLimit {
+				stats.CompleteWeekends++
+			}
+		}
+
+		for _, membership := range history {
+			if counted[membership.PlayerTag] || membership.ClanTag != weekend.ClanTag || !membership.Covers(weekend.StartTime, weekend.EndTime) {
+				continue
+			}
+			counted[membership.PlayerTag] = true
+
+			name, ok := names[membership.PlayerTag]
+			if !ok {
+				name = membership.PlayerTag
+			}
+			stats := statsOf(membership.PlayerTag, name)
+			stats.Weekends++
+			stats.AttackLimit += raidAttackLimit
+		}
+	}
+
+	stats := make([]*types.RaidPlayerStats, 0, len(statsByTag))
+	for _, s := range statsByTag {
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b *types.RaidPlayerStats) int {
+		if c := cmp.Compare(b.Looted, a.Looted); c != 0 {
+			return c
+		}
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return stats
+}
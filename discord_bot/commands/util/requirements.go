@@ -0,0 +1,176 @@
+package util
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aaantiii/goclash"
+
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+// CheckClanRequirements compares the player with the requirements of the clan. Requirements which are not set are skipped.
+func CheckClanRequirements(settings *models.ClanSettings, player *goclash.Player) []*types.Requirement {
+	var requirements []*types.Requirement
+	if settings.MinTownHallLevel > 0 {
+		requirements = append(requirements, &types.Requirement{
+			Name:     "Rathaus",
+			Required: strconv.Itoa(settings.MinTownHallLevel),
+			Actual:   strconv.Itoa(player.TownHallLevel),
+			Met:      player.TownHallLevel >= settings.MinTownHallLevel,
+		})
+	}
+	if settings.MinHeroLevels > 0 {
+		heroLevels := HeroLevelSum(player)
+		requirements = append(requirements, &types.Requirement{
+			Name:     "Heldenlevel (Summe)",
+			Required: strconv.Itoa(settings.MinHeroLevels),
+			Actual:   strconv.Itoa(heroLevels),
+			Met:      heroLevels >= settings.MinHeroLevels,
+		})
+	}
+	if settings.MinWarStars > 0 {
+		requirements = append(requirements, &types.Requirement{
+			Name:     "Kriegssterne",
+			Required: strconv.Itoa(settings.MinWarStars),
+			Actual:   strconv.Itoa(player.WarStars),
+			Met:      player.WarStars >= settings.MinWarStars,
+		})
+	}
+	if settings.MinLeagueID > 0 {
+		requirements = append(requirements, &types.Requirement{
+			Name:     "Liga",
+			Required: types.HomeLeagueName(settings.MinLeagueID),
+			Actual:   types.HomeLeagueName(player.League.ID),
+			Met:      player.League.ID >= settings.MinLeagueID,
+		})
+	}
+	return requirements
+}
+
+// HeroLevelSum returns the sum of the levels of all home village heroes.
+func HeroLevelSum(player *goclash.Player) int {
+	var sum int
+	for _, hero := range player.Heroes {
+		if hero.Village == goclash.VillageHome {
+			sum += hero.Level
+		}
+	}
+	return sum
+}
+
+// ScoutHeroes compares the home village heroes of the player with the maximum levels of its town hall.
+func ScoutHeroes(player *goclash.Player) []*types.ScoutedHero {
+	var heroes []*types.ScoutedHero
+	for _, hero := range player.Heroes {
+		if hero.Village != goclash.VillageHome {
+			continue
+		}
+
+		maxLevel := types.HeroMaxLevel(hero.Name, player.TownHallLevel)
+		if maxLevel == 0 {
+			maxLevel = hero.MaxLevel
+		}
+		heroes = append(heroes, &types.ScoutedHero{
+			Name:      hero.Name,
+			Level:     hero.Level,
+			MaxLevel:  maxLevel,
+			RushLevel: types.HeroMaxLevel(hero.Name, player.TownHallLevel-1),
+		})
+	}
+	return heroes
+}
+
+// RankPromotionCandidates checks the candidates against the promotion criteria of the clan and sorts them by the number of met criteria.
+// Candidates meeting the same number of criteria are sorted by tenure and donations.
+func RankPromotionCandidates(settings *models.ClanSettings, candidates []*types.PromotionCandidate) {
+	for _, candidate := range candidates {
+		candidate.Requirements = promotionRequirements(settings, candidate)
+	}
+
+	slices.SortStableFunc(candidates, func(a, b *types.PromotionCandidate) int {
+		if c := cmp.Compare(b.MetCount(), a.MetCount()); c != 0 {
+			return c
+		}
+		if c := compareJoinedAt(a.JoinedAt, b.JoinedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(candidateDonations(b), candidateDonations(a))
+	})
+}
+
+func promotionRequirements(settings *models.ClanSettings, candidate *types.PromotionCandidate) []*types.Requirement {
+	tenure := &types.Requirement{
+		Name:     "Mitgliedschaft",
+		Required: fmt.Sprintf("%d Tage", settings.PromotionMinDays),
+		Actual:   "vor Aufzeichnung",
+		Met:      true, // members from before the history was recorded have been in the clan for a long time
+	}
+	if candidate.JoinedAt != nil {
+		days := int(time.Since(*candidate.JoinedAt).Hours() / 24)
+		tenure.Actual = fmt.Sprintf("%d Tage", days)
+		tenure.Met = days >= settings.PromotionMinDays
+	}
+
+	requirements := []*types.Requirement{tenure, {
+		Name:     "Kickpunkte",
+		Required: fmt.Sprintf("max. %d", settings.PromotionMaxKickpoints),
+		Actual:   strconv.Itoa(candidate.Kickpoints),
+		Met:      candidate.Kickpoints <= settings.PromotionMaxKickpoints,
+	}, warAttackRequirement(settings, candidate.WarAttacks)}
+
+	if candidate.ClashPlayer == nil {
+		return append(requirements, &types.Requirement{
+			Name:   "Live-Daten",
+			Actual: "nicht verfügbar",
+		})
+	}
+
+	return append(requirements, &types.Requirement{
+		Name:     "Spenden",
+		Required: strconv.Itoa(settings.PromotionMinDonations),
+		Actual:   strconv.Itoa(candidate.ClashPlayer.Donations),
+		Met:      candidate.ClashPlayer.Donations >= settings.PromotionMinDonations,
+	})
+}
+
+// warAttackRequirement checks the share of used war attacks. Members without war data only meet it without a minimum.
+func warAttackRequirement(settings *models.ClanSettings, usage *types.WarAttackUsage) *types.Requirement {
+	requirement := &types.Requirement{
+		Name:     "Kriegsangriffe",
+		Required: fmt.Sprintf("%d%%", settings.PromotionMinAttackRate),
+		Actual:   "keine Kriegsdaten",
+		Met:      settings.PromotionMinAttackRate == 0,
+	}
+	if usage != nil {
+		rate := usage.Rate() * 100
+		requirement.Actual = fmt.Sprintf("%d/%d (%.0f%%)", usage.Used, usage.Available, rate)
+		requirement.Met = rate >= float64(settings.PromotionMinAttackRate)
+	}
+	return requirement
+}
+
+// compareJoinedAt sorts earlier join dates first. Unknown join dates are the earliest.
+func compareJoinedAt(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+func candidateDonations(candidate *types.PromotionCandidate) int {
+	if candidate.ClashPlayer == nil {
+		return 0
+	}
+	return candidate.ClashPlayer.Donations
+}
@@ -0,0 +1,224 @@
+package util
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/aaantiii/goclash"
+
+	"bot/store/postgres/models"
+	"bot/types"
+)
+
+// WarAttacksPerMember is the number of attacks each member has in a regular clan war.
+const WarAttacksPerMember = 2
+
+const (
+	// townHallThreat is the threat of a single town hall level, measured in hero levels.
+	townHallThreat = 20
+	// warStarsPerThreat is the number of war stars counting as a single hero level of threat.
+	warStarsPerThreat = 100
+)
+
+// WarEnded reports whether the war is over. The API reports "warEnded", while goclash defines the state as "ended".
+func WarEnded(war *goclash.ClanWar) bool {
+	return war.State == "warEnded" || war.State == goclash.ClanWarStateEnded
+}
+
+// WarResult returns the result of the war from the view of the clan. Stars decide first, then destruction.
+func WarResult(war *goclash.ClanWar) models.WarResult {
+	switch {
+	case war.Clan.Stars > war.Opponent.Stars:
+		return models.WarResultWin
+	case war.Clan.Stars < war.Opponent.Stars:
+		return models.WarResultLose
+	case war.Clan.DestructionPercentage > war.Opponent.DestructionPercentage:
+		return models.WarResultWin
+	case war.Clan.DestructionPercentage < war.Opponent.DestructionPercentage:
+		return models.WarResultLose
+	default:
+		return models.WarResultTie
+	}
+}
+
+// TownHallCounts returns how many members of the war clan have each town hall level.
+func TownHallCounts(clan goclash.WarClan) map[int]int {
+	counts := make(map[int]int)
+	for _, member := range clan.Members {
+		counts[member.TownHallLevel]++
+	}
+	return counts
+}
+
+// ApplyWarResult sets the result, the lineup and the attacks of the finished war on the stored war.
+func ApplyWarResult(war *models.ClanWar, clanWar *goclash.ClanWar) {
+	war.Result = WarResult(clanWar)
+	war.Stars = clanWar.Clan.Stars
+	war.Destruction = clanWar.Clan.DestructionPercentage
+	war.OpponentStars = clanWar.Opponent.Stars
+	war.OpponentDestruction = clanWar.Opponent.DestructionPercentage
+
+	defenderByTag := make(map[string]goclash.ClanWarMember, len(clanWar.Opponent.Members))
+	for _, member := range clanWar.Opponent.Members {
+		defenderByTag[member.Tag] = member
+	}
+
+	war.Participants = make([]*models.WarParticipant, 0, len(clanWar.Clan.Members))
+	war.Attacks = nil
+	for _, member := range clanWar.Clan.Members {
+		war.Participants = append(war.Participants, &models.WarParticipant{
+			PlayerTag:     member.Tag,
+			Name:          member.Name,
+			TownHallLevel: member.TownHallLevel,
+			MapPosition:   member.MapPosition,
+			Attacks:       len(member.Attacks),
+		})
+
+		for _, attack := range member.Attacks {
+			defender := defenderByTag[attack.DefenderTag]
+			war.Attacks = append(war.Attacks, &models.WarAttack{
+				Order:                 attack.Order,
+				AttackerTag:           attack.AttackerTag,
+				AttackerTownHallLevel: member.TownHallLevel,
+				DefenderTag:           attack.DefenderTag,
+				DefenderTownHallLevel: defender.TownHallLevel,
+				DefenderMapPosition:   defender.MapPosition,
+				Stars:                 attack.Stars,
+				Destruction:           attack.DestructionPercentage,
+			})
+		}
+	}
+}
+
+// WarPlayerStatistics aggregates the attacks of the finished wars per player, sorted by hit rate and attacks.
+// The wars must be loaded with their participants and attacks.
+func WarPlayerStatistics(wars []*models.ClanWar) []*types.WarPlayerStats {
+	statsByTag := make(map[string]*types.WarPlayerStats)
+	for _, war := range wars {
+		for _, participant := range war.Participants {
+			stats, ok := statsByTag[participant.PlayerTag]
+			if !ok {
+				stats = &types.WarPlayerStats{
+					PlayerTag:      participant.PlayerTag,
+					Name:           participant.Name,
+					ByTownHallDiff: make(map[int]*types.WarStarAverage),
+				}
+				statsByTag[participant.PlayerTag] = stats
+			}
+			stats.Wars++
+			stats.MissedAttacks += max(WarAttacksPerMember-participant.Attacks, 0)
+		}
+
+		for _, attack := range war.Attacks {
+			stats, ok := statsByTag[attack.AttackerTag]
+			if !ok {
+				continue
+			}
+			stats.Attacks++
+			stats.Stars += attack.Stars
+			if attack.Stars == 3 {
+				stats.ThreeStars++
+			}
+
+			diff := attack.DefenderTownHallLevel - attack.AttackerTownHallLevel
+			average, ok := stats.ByTownHallDiff[diff]
+			if !ok {
+				average = &types.WarStarAverage{}
+				stats.ByTownHallDiff[diff] = average
+			}
+			average.Attacks++
+			average.Stars += attack.Stars
+		}
+	}
+
+	stats := make([]*types.WarPlayerStats, 0, len(statsByTag))
+	for _, s := range statsByTag {
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b *types.WarPlayerStats) int {
+		if c := cmp.Compare(b.HitRate(), a.HitRate()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Attacks, a.Attacks); c != 0 {
+			return c
+		}
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return stats
+}
+
+// TownHallDiffAverages combines the average stars by town hall difference of all players.
+func TownHallDiffAverages(stats []*types.WarPlayerStats) map[int]*types.WarStarAverage {
+	averages := make(map[int]*types.WarStarAverage)
+	for _, s := range stats {
+		for diff, average := range s.ByTownHallDiff {
+			combined, ok := averages[diff]
+			if !ok {
+				combined = &types.WarStarAverage{}
+				averages[diff] = combined
+			}
+			combined.Attacks += average.Attacks
+			combined.Stars += average.Stars
+		}
+	}
+	return averages
+}
+
+// ScoutWar compares both lineups of the war using the live data of the players.
+// Players missing from playerByTag are scouted with the data of the war only and are left out of the matchups,
+// as their hero levels and war stars are unknown.
+func ScoutWar(war *goclash.ClanWar, playerByTag map[string]*goclash.Player) *types.WarScout {
+	scout := &types.WarScout{
+		Clan:     scoutWarClan(war.Clan, playerByTag),
+		Opponent: scoutWarClan(war.Opponent, playerByTag),
+	}
+
+	opponentByPosition := make(map[int]*types.WarScoutPlayer, len(scout.Opponent))
+	for _, opponent := range scout.Opponent {
+		opponentByPosition[opponent.MapPosition] = opponent
+	}
+	for _, member := range scout.Clan {
+		opponent, ok := opponentByPosition[member.MapPosition]
+		if !ok || !member.LiveData || !opponent.LiveData {
+			continue
+		}
+		scout.Matchups = append(scout.Matchups, &types.WarScoutMatchup{
+			Member:   member,
+			Opponent: opponent,
+			Threat:   matchupThreat(member, opponent),
+		})
+	}
+	slices.SortStableFunc(scout.Matchups, func(a, b *types.WarScoutMatchup) int {
+		return cmp.Compare(b.Threat, a.Threat)
+	})
+
+	return scout
+}
+
+func scoutWarClan(clan goclash.WarClan, playerByTag map[string]*goclash.Player) []*types.WarScoutPlayer {
+	players := make([]*types.WarScoutPlayer, len(clan.Members))
+	for i, member := range clan.Members {
+		players[i] = &types.WarScoutPlayer{
+			Tag:           member.Tag,
+			Name:          member.Name,
+			MapPosition:   member.MapPosition,
+			TownHallLevel: member.TownHallLevel,
+		}
+		if player, ok := playerByTag[member.Tag]; ok {
+			players[i].HeroLevels = HeroLevelSum(player)
+			players[i].WarStars = player.WarStars
+			players[i].LiveData = true
+		}
+	}
+	slices.SortFunc(players, func(a, b *types.WarScoutPlayer) int {
+		return cmp.Compare(a.MapPosition, b.MapPosition)
+	})
+	return players
+}
+
+// matchupThreat measures how much stronger the opponent is, in hero levels. Town halls weigh the most, war stars hint at experience.
+func matchupThreat(member, opponent *types.WarScoutPlayer) float64 {
+	return float64((opponent.TownHallLevel-member.TownHallLevel)*townHallThreat+opponent.HeroLevels-member.HeroLevels) +
+		float64(opponent.WarStars-member.WarStars)/warStarsPerThreat
+}
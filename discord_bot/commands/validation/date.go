@@ -1,6 +1,12 @@
 package validation
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// MaxAbsenceDays is the maximum length of an absence in days.
+const MaxAbsenceDays = 60
 
 func ValidateEventDates(startsAt, endsAt time.Time) (string, bool) {
 	if startsAt.Before(time.Now()) {
@@ -11,3 +17,17 @@ func ValidateEventDates(startsAt, endsAt time.Time) (string, bool) {
 	}
 	return "", true
 }
+
+// ValidateAbsenceDates validates the first and the last day of an absence.
+func ValidateAbsenceDates(firstDay, lastDay time.Time) (string, bool) {
+	if lastDay.Before(firstDay) {
+		return "Der letzte Tag der Abwesenheit darf nicht vor dem ersten Tag liegen.", false
+	}
+	if lastDay.AddDate(0, 0, 1).Before(time.Now()) {
+		return "Die Abwesenheit darf nicht in der Vergangenheit liegen.", false
+	}
+	if lastDay.After(firstDay.AddDate(0, 0, MaxAbsenceDays-1)) {
+		return fmt.Sprintf("Eine Abwesenheit darf höchstens %d Tage dauern.", MaxAbsenceDays), false
+	}
+	return "", true
+}
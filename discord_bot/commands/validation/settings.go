@@ -2,6 +2,8 @@ package validation
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"bot/store/postgres/models"
 )
@@ -28,3 +30,57 @@ func ValidateClanSettings(setting *models.ClanSettings) (string, bool) {
 	}
 	return "", true
 }
+
+const (
+	MaxWarReminders    = 5
+	MaxWarReminderHour = 23
+)
+
+// ValidateWarReminderHours validates a comma separated list of hours before the end of a war.
+func ValidateWarReminderHours(hours string) (string, bool) {
+	values := strings.Split(hours, ",")
+	if len(values) > MaxWarReminders {
+		return fmt.Sprintf("Es können höchstens %d Erinnerungen festgelegt werden.", MaxWarReminders), false
+	}
+	for _, value := range values {
+		hour, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || hour < 1 || hour > MaxWarReminderHour {
+			return fmt.Sprintf("Die Stunden müssen durch Kommas getrennte Zahlen zwischen 1 und %d sein, z.B. `12,4,1`.", MaxWarReminderHour), false
+		}
+	}
+	return "", true
+}
+
+const (
+	MinCWDonorRangeSize = 5
+	MaxCWDonorRangeSize = 50
+)
+
+const (
+	MinWarCallExpiryMinutes  = 15
+	MaxWarCallExpiryMinutes  = 24 * 60
+	MaxWarCallTownHallsBelow = 16
+)
+
+// MaxRaidReminders is the maximum number of raid reminders per clan.
+const MaxRaidReminders = 5
+
+// ValidateRaidReminderTimes validates a comma separated list of weekdays of the raid weekend and times.
+func ValidateRaidReminderTimes(times string) (string, bool) {
+	values := strings.Split(times, ",")
+	if len(values) > MaxRaidReminders {
+		return fmt.Sprintf("Es können höchstens %d Erinnerungen festgelegt werden.", MaxRaidReminders), false
+	}
+	for _, value := range values {
+		if _, ok := models.ParseRaidReminderTime(value); !ok {
+			return "Die Zeiten müssen durch Kommas getrennt aus einem Tag des Raid Wochenendes (Fr, Sa, So, Mo) und einer Uhrzeit bestehen, z.B. `So 18:00,Mo 06:00`.", false
+		}
+	}
+	return "", true
+}
+
+// MaxClanGamesMinPoints is the highest minimum of Clan Games points, the most points a member can earn.
+const MaxClanGamesMinPoints = 4000
+
+// MaxMinDonationRatio is the highest minimum donation ratio a clan can set.
+const MaxMinDonationRatio = 10
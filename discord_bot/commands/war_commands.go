@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+
+	"bot/commands/handlers"
+	"bot/commands/middleware"
+	"bot/commands/repos"
+	"bot/commands/util"
+	"bot/commands/validation"
+	"bot/types"
+)
+
+func warInteractionCommands(db *gorm.DB, clashClient *goclash.Client) types.Commands[types.InteractionHandler] {
+	handler := handlers.NewWarHandler(
+		repos.NewClansRepo(db),
+		repos.NewPlayersRepo(db),
+		repos.NewMembersRepo(db),
+		repos.NewClanSettingsRepo(db),
+		repos.NewWarsRepo(db),
+		repos.NewAbsencesRepo(db),
+		repos.NewCWDonorsRepo(db),
+		middleware.NewAuthMiddleware(repos.NewGuildsRepo(db), repos.NewClansRepo(db), repos.NewUsersRepo(db)),
+		clashClient,
+	)
+
+	return types.Commands[types.InteractionHandler]{{
+		Handler: types.InteractionHandler{
+			Main:         handler.WarReminders,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "warreminders",
+			Description:  "Legt fest, wann Mitglieder mit offenen Kriegsangriffen erinnert werden.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Erinnerungen festgelegt werden sollen."),
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        handlers.HoursOptionName,
+					Description: "Stunden vor Kriegsende, durch Kommas getrennt (z.B. 12,4,1). 0 deaktiviert die Erinnerungen.",
+					Required:    true,
+					MinLength:   util.IntPtr(1),
+					MaxLength:   20,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.CWDonorSettings,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "cwdonorsettings",
+			Description:  "Legt fest, ob Spender automatisch eingeteilt werden und wie viele Positionen ein Spender übernimmt.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Einstellungen festgelegt werden sollen."),
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        handlers.AutoPostOptionName,
+					Description: "Ob die Spender zu Beginn der Vorbereitung automatisch im Kriegs-Channel eingeteilt werden.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.RangeSizeOptionName,
+					Description: "Anzahl der Positionen, für die ein Spender zuständig ist.",
+					MinValue:    util.FloatPtr(validation.MinCWDonorRangeSize),
+					MaxValue:    validation.MaxCWDonorRangeSize,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.WarCallSettings,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "warcallsettings",
+			Description:  "Legt fest, wie lange Calls gültig sind und wie weit Mitglieder nach unten callen dürfen.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Einstellungen festgelegt werden sollen."),
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.ExpiryOptionName,
+					Description: "Minuten, nach denen ein Call abläuft.",
+					MinValue:    util.FloatPtr(validation.MinWarCallExpiryMinutes),
+					MaxValue:    validation.MaxWarCallExpiryMinutes,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        handlers.TownHallsBelowOptionName,
+					Description: "Wie viele Rathaus-Level unter dem eigenen eine Base liegen darf, die gecallt wird.",
+					MinValue:    util.FloatPtr(0),
+					MaxValue:    validation.MaxWarCallTownHallsBelow,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Call,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "call",
+			Description:  "Reserviert eine gegnerische Base im aktuellen Clan Krieg.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, in dessen Krieg du callen möchtest."),
+				optionWarTarget("Position der gegnerischen Base, die du angreifen möchtest."),
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         handlers.MyPlayerTagOptionName,
+					Description:  "Account, mit dem du angreifst. Nur nötig, wenn du mehrere Accounts im Krieg hast.",
+					MinLength:    util.IntPtr(validation.TagMinLength),
+					MaxLength:    validation.TagMaxLength,
+					Autocomplete: true,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.Uncall,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "uncall",
+			Description:  "Gibt eine gecallte Base im aktuellen Clan Krieg wieder frei.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, in dessen Krieg der Call entfernt werden soll."),
+				optionWarTarget("Position der gegnerischen Base, deren Call entfernt werden soll."),
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.WarStats,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "warstats",
+			Description:  "Zeigt Trefferquoten, Sterne nach Rathaus-Differenz und fehlende Angriffe der letzten Kriege.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Kriegsstatistiken angezeigt werden sollen."),
+				optionWarCount(),
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         handlers.PlayerTagOptionName,
+					Description:  "Spieler, dessen Statistiken einzeln angezeigt werden sollen.",
+					Autocomplete: true,
+					MinLength:    util.IntPtr(validation.TagMinLength),
+					MaxLength:    validation.TagMaxLength,
+				},
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.WarLog,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "warlog",
+			Description:  "Zeigt die Ergebnisse der letzten Kriege eines Clans.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen Kriegslog angezeigt werden soll."),
+				optionWarCount(),
+			},
+		},
+	}, {
+		Handler: types.InteractionHandler{
+			Main:         handler.WarScout,
+			Autocomplete: handler.HandleAutocomplete,
+		},
+		ApplicationCommand: &discordgo.ApplicationCommand{
+			Name:         "warscout",
+			Description:  "Vergleicht die Aufstellung des aktuellen Krieges mit der des Gegners.",
+			Type:         discordgo.ChatApplicationCommand,
+			DMPermission: util.BoolPtr(false),
+			Options: []*discordgo.ApplicationCommandOption{
+				optionClanTag("Clan, dessen aktueller Krieg gescoutet werden soll."),
+			},
+		},
+	}}
+}
@@ -39,3 +39,6 @@ require (
 	golang.org/x/sync v0.9.0 // indirect
 	golang.org/x/sys v0.27.0 // indirect
 )
+
+// GetClanWarLeagueWar upstream decodes league wars into ClanWarLeagueGroup.
+replace github.com/aaantiii/goclash => ./third_party/goclash
@@ -52,6 +52,36 @@ func migrateAndSeedDB(db *gorm.DB) error {
 		// Event-related models
 		&models.ClanEvent{},
 		&models.ClanEventMember{},
+
+		// Leader tools
+		&models.PlayerNote{},
+		&models.Nickname{},
+		&models.MemberHistory{},
+		&models.BlacklistEntry{},
+		&models.Absence{},
+
+		// Wars
+		&models.ClanWar{},
+		&models.WarParticipant{},
+		&models.WarAttack{},
+		&models.WarCall{},
+		&models.CWDonorAssignment{},
+		&models.CWDonorOptOut{},
+
+		// CWL
+		&models.CWLSignup{},
+
+		// Raids
+		&models.RaidWeekend{},
+		&models.RaidMember{},
+		&models.RaidDistrict{},
+
+		// Clan Games
+		&models.ClanGames{},
+		&models.ClanGamesMember{},
+
+		// Donations
+		&models.DonationSnapshot{},
 	); err != nil {
 		return err
 	}
@@ -61,6 +91,41 @@ func migrateAndSeedDB(db *gorm.DB) error {
 		return err
 	}
 
+	// Backfill data of features added after the data was created
+	if err := backfillData(db); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func backfillData(db *gorm.DB) error {
+	// Create the nicknames of users verified before nicknames were stored, so that they are synced after a name change.
+	// The account in a clan is preferred, the current name is assumed to be applied already.
+	if err := db.Exec(`
+		INSERT INTO nicknames (discord_id, player_tag, alias, applied_nick, sync_disabled)
+		SELECT DISTINCT ON (discord_id) discord_id, coc_tag, '', LEFT(name, 32), false
+		FROM players
+		WHERE discord_id <> ''
+		ORDER BY discord_id, coc_tag IN (SELECT player_tag FROM clan_members) DESC, coc_tag
+		ON CONFLICT (discord_id) DO NOTHING
+	`).Error; err != nil {
+		log.Printf("Warning: Could not backfill nicknames: %v", err)
+	}
+
+	// Open the history of memberships which existed before the history was recorded, the join date is unknown.
+	if err := db.Exec(`
+		INSERT INTO member_histories (player_tag, clan_tag, clan_role)
+		SELECT m.player_tag, m.clan_tag, m.clan_role
+		FROM clan_members m
+		WHERE NOT EXISTS (
+			SELECT 1 FROM member_histories h
+			WHERE h.player_tag = m.player_tag AND h.clan_tag = m.clan_tag AND h.left_at IS NULL
+		)
+	`).Error; err != nil {
+		log.Printf("Warning: Could not backfill member histories: %v", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Absence is a period in which a member is away. EndsAt is the start of the day the member is back.
+type Absence struct {
+	ID                 uint      `gorm:"primaryKey"`
+	PlayerTag          string    `gorm:"size:12;not null;index"`
+	StartsAt           time.Time `gorm:"not null"`
+	EndsAt             time.Time `gorm:"not null"`
+	Reason             string    `gorm:"size:200;not null"`
+	CreatedByDiscordID string    `gorm:"size:19;not null"`
+	CreatedAt          time.Time
+
+	Player *Player `gorm:"foreignKey:CocTag;references:PlayerTag"`
+}
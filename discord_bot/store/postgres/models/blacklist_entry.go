@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// BlacklistEntry is a player who must not be verified or added to any family clan. Entries without ExpiresAt never expire.
+type BlacklistEntry struct {
+	PlayerTag          string `gorm:"size:12;primaryKey;not null"`
+	Reason             string `gorm:"size:200;not null"`
+	CreatedByDiscordID string `gorm:"size:19;not null"`
+	CreatedAt          time.Time
+	ExpiresAt          *time.Time
+
+	Player        *Player `gorm:"foreignKey:CocTag;references:PlayerTag"`
+	CreatedByUser *User   `gorm:"foreignKey:DiscordID;references:CreatedByDiscordID"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// ClanGames are the monthly Clan Games of a family clan followed by the clan games tracker.
+type ClanGames struct {
+	ID             uint      `gorm:"primaryKey"`
+	ClanTag        string    `gorm:"size:12;not null;uniqueIndex:idx_clan_games"`
+	StartTime      time.Time `gorm:"not null;uniqueIndex:idx_clan_games"`
+	EndTime        time.Time `gorm:"not null"`
+	LastProgressAt *time.Time
+	FinishedAt     *time.Time
+
+	Clan    *Clan              `gorm:"foreignKey:Tag;references:ClanTag"`
+	Members []*ClanGamesMember `gorm:"foreignKey:ClanGamesID;constraint:OnDelete:CASCADE"`
+}
+
+// ClanGamesMember holds the Games Champion achievement value of a member at the start of the Clan Games and the latest value.
+type ClanGamesMember struct {
+	ClanGamesID uint   `gorm:"primaryKey"`
+	PlayerTag   string `gorm:"size:12;primaryKey"`
+	Name        string `gorm:"size:50;not null"`
+	StartPoints int    `gorm:"not null"`               // value at the start, or when the member joined during the Clan Games
+	Points      int    `gorm:"not null"`               // latest value
+	LateStart   bool   `gorm:"not null;default:false"` // the start value was taken well after the start, so earned points may be missing
+}
+
+// Earned returns the points the member has earned during the Clan Games.
+func (m *ClanGamesMember) Earned() int {
+	return m.Points - m.StartPoints
+}
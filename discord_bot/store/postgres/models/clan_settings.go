@@ -1,14 +1,41 @@
 package models
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata" // the reminder time zone must be available without zoneinfo on the host
 )
 
 type ClanSettings struct {
-	ClanTag                   string `gorm:"size:12;primaryKey;not null"`
-	MaxKickpoints             int    `gorm:"not null;default:6"`
-	MinSeasonWins             int    `gorm:"not null;default:80"`
-	KickpointsExpireAfterDays int    `gorm:"not null;default:45"`
+	ClanTag                   string  `gorm:"size:12;primaryKey;not null"`
+	MaxKickpoints             int     `gorm:"not null;default:6"`
+	MinSeasonWins             int     `gorm:"not null;default:80"`
+	KickpointsExpireAfterDays int     `gorm:"not null;default:45"`
+	LeaderChannelID           string  `gorm:"size:19"`
+	RecruitmentChannelID      string  `gorm:"size:19"`
+	MinTownHallLevel          int     `gorm:"not null;default:0"`
+	MinHeroLevels             int     `gorm:"not null;default:0"`
+	MinWarStars               int     `gorm:"not null;default:0"`
+	MinLeagueID               int     `gorm:"not null;default:0"`
+	PromotionMinDays          int     `gorm:"not null;default:30"`
+	PromotionMinDonations     int     `gorm:"not null;default:500"`
+	PromotionMinAttackRate    int     `gorm:"not null;default:0"` // percentage of war attacks used in the last stored wars
+	PromotionMaxKickpoints    int     `gorm:"not null;default:0"`
+	WarChannelID              string  `gorm:"size:19"`
+	WarThreadChannelID        string  `gorm:"size:19"`                        // channel in which a thread is opened for every war
+	WarReminderHours          string  `gorm:"size:50;not null;default:'4,1'"` // comma separated hours before the end of a war, empty disables reminders
+	CWDonorAutoPost           bool    `gorm:"not null;default:false"`         // post the donors in the war channel when the preparation starts
+	CWDonorRangeSize          int     `gorm:"not null;default:10"`            // map positions per donor
+	WarCallExpiryMinutes      int     `gorm:"not null;default:120"`
+	WarCallMaxTownHallsBelow  int     `gorm:"not null;default:1"` // how many town hall levels below their own members may call
+	RaidChannelID             string  `gorm:"size:19"`
+	RaidReminderTimes         string  `gorm:"size:100;not null;default:''"` // comma separated weekdays and times like "So 18:00" in German time, empty disables reminders
+	ClanGamesChannelID        string  `gorm:"size:19"`
+	ClanGamesMinPoints        int     `gorm:"not null;default:0"` // members below are flagged for kickpoints, 0 disables the check
+	MinDonationRatio          float64 `gorm:"not null;default:0"` // members below are flagged as freeloaders by /donations, 0 disables the check
 	UpdatedAt                 time.Time
 	UpdatedByDiscordID        *string
 
@@ -16,3 +43,164 @@ type ClanSettings struct {
 	KickpointReasons []*KickpointReason `gorm:"foreignKey:ClanTag;references:ClanTag"`
 	UpdatedByUser    *User              `gorm:"foreignKey:DiscordID;references:UpdatedByDiscordID"`
 }
+
+// ClanChannel is a Discord channel the bot posts clan specific messages in.
+type ClanChannel string
+
+const (
+	ClanChannelLeader      ClanChannel = "leader"
+	ClanChannelRecruitment ClanChannel = "recruitment"
+	ClanChannelWar         ClanChannel = "war"
+	ClanChannelWarThread   ClanChannel = "war_thread"
+	ClanChannelRaid        ClanChannel = "raid"
+	ClanChannelClanGames   ClanChannel = "clan_games"
+)
+
+func (c ClanChannel) String() string {
+	return string(c)
+}
+
+func (c ClanChannel) Format() string {
+	switch c {
+	case ClanChannelLeader:
+		return "Leader-Benachrichtigungen"
+	case ClanChannelRecruitment:
+		return "Bewerbungen"
+	case ClanChannelWar:
+		return "Kriegsnachrichten"
+	case ClanChannelWarThread:
+		return "Kriegs-Threads"
+	case ClanChannelRaid:
+		return "Raid-Erinnerungen"
+	case ClanChannelClanGames:
+		return "Clan Spiele"
+	default:
+		return "Unbekannter Channel"
+	}
+}
+
+// ChannelID returns the id of the channel of the given type, or an empty string if it is not set.
+func (s *ClanSettings) ChannelID(channel ClanChannel) string {
+	switch channel {
+	case ClanChannelLeader:
+		return s.LeaderChannelID
+	case ClanChannelRecruitment:
+		return s.RecruitmentChannelID
+	case ClanChannelWar:
+		return s.WarChannelID
+	case ClanChannelWarThread:
+		return s.WarThreadChannelID
+	case ClanChannelRaid:
+		return s.RaidChannelID
+	case ClanChannelClanGames:
+		return s.ClanGamesChannelID
+	default:
+		return ""
+	}
+}
+
+// SetChannelID sets the id of the channel of the given type. Returns false if the type is unknown.
+func (s *ClanSettings) SetChannelID(channel ClanChannel, channelID string) bool {
+	switch channel {
+	case ClanChannelLeader:
+		s.LeaderChannelID = channelID
+	case ClanChannelRecruitment:
+		s.RecruitmentChannelID = channelID
+	case ClanChannelWar:
+		s.WarChannelID = channelID
+	case ClanChannelWarThread:
+		s.WarThreadChannelID = channelID
+	case ClanChannelRaid:
+		s.RaidChannelID = channelID
+	case ClanChannelClanGames:
+		s.ClanGamesChannelID = channelID
+	default:
+		return false
+	}
+	return true
+}
+
+// Column returns the name of the database column holding the id of the channel.
+func (c ClanChannel) Column() string {
+	return string(c) + "_channel_id"
+}
+
+// WarReminders returns the hours before the end of a war at which members with unused attacks are reminded, starting with the earliest reminder.
+func (s *ClanSettings) WarReminders() []int {
+	var hours []int
+	for _, value := range strings.Split(s.WarReminderHours, ",") {
+		if hour, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && hour > 0 {
+			hours = append(hours, hour)
+		}
+	}
+	slices.Sort(hours)
+	slices.Reverse(hours)
+	return slices.Compact(hours)
+}
+
+// RaidReminders returns the times of the week at which members with unused raid attacks are reminded. Invalid times are skipped.
+func (s *ClanSettings) RaidReminders() []RaidReminderTime {
+	var times []RaidReminderTime
+	for _, value := range strings.Split(s.RaidReminderTimes, ",") {
+		if t, ok := ParseRaidReminderTime(value); ok {
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
+// RaidReminderTime is a time of the raid weekend at which members with unused raid attacks are reminded.
+type RaidReminderTime struct {
+	Weekday time.Weekday
+	Hour    int
+	Minute  int
+}
+
+// raidReminderLocation is the time zone in which the reminder times are entered and evaluated.
+var raidReminderLocation = mustLoadLocation("Europe/Berlin")
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// raidWeekdays are the days of a raid weekend by their German abbreviation.
+var raidWeekdays = map[string]time.Weekday{
+	"Fr": time.Friday,
+	"Sa": time.Saturday,
+	"So": time.Sunday,
+	"Mo": time.Monday,
+}
+
+// ParseRaidReminderTime parses a weekday of the raid weekend and a time like "So 18:00".
+func ParseRaidReminderTime(value string) (RaidReminderTime, bool) {
+	day, clock, ok := strings.Cut(strings.TrimSpace(value), " ")
+	weekday, validDay := raidWeekdays[day]
+	if !ok || !validDay {
+		return RaidReminderTime{}, false
+	}
+
+	parsed, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return RaidReminderTime{}, false
+	}
+	return RaidReminderTime{Weekday: weekday, Hour: parsed.Hour(), Minute: parsed.Minute()}, true
+}
+
+// Matches reports whether the reminder is due in the minute of now, in German time.
+func (t RaidReminderTime) Matches(now time.Time) bool {
+	now = now.In(raidReminderLocation)
+	return now.Weekday() == t.Weekday && now.Hour() == t.Hour && now.Minute() == t.Minute
+}
+
+func (t RaidReminderTime) String() string {
+	for day, weekday := range raidWeekdays {
+		if weekday == t.Weekday {
+			return fmt.Sprintf("%s %02d:%02d", day, t.Hour, t.Minute)
+		}
+	}
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRaidReminderTime(t *testing.T) {
+	tests := []struct {
+		value string
+		want  RaidReminderTime
+		ok    bool
+	}{
+		{value: "So 18:00", want: RaidReminderTime{Weekday: time.Sunday, Hour: 18}, ok: true},
+		{value: "Mo 06:00", want: RaidReminderTime{Weekday: time.Monday, Hour: 6}, ok: true},
+		{value: " Fr 07:30 ", want: RaidReminderTime{Weekday: time.Friday, Hour: 7, Minute: 30}, ok: true},
+		{value: "Sa  23:59", want: RaidReminderTime{Weekday: time.Saturday, Hour: 23, Minute: 59}, ok: true},
+		{value: "So 6:00", want: RaidReminderTime{Weekday: time.Sunday, Hour: 6}, ok: true},
+		{value: "Di 18:00"},
+		{value: "So 24:00"},
+		{value: "So"},
+		{value: "18:00"},
+		{value: ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseRaidReminderTime(tt.value)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseRaidReminderTime(%q) = %v, %t, want %v, %t", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRaidReminderTimeMatches(t *testing.T) {
+	reminder := RaidReminderTime{Weekday: time.Monday, Hour: 6}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "summer time", now: time.Date(2026, time.July, 6, 4, 0, 30, 0, time.UTC), want: true},
+		{name: "winter time", now: time.Date(2026, time.January, 5, 5, 0, 0, 0, time.UTC), want: true},
+		{name: "UTC clock", now: time.Date(2026, time.January, 5, 6, 0, 0, 0, time.UTC)},
+		{name: "next minute", now: time.Date(2026, time.January, 5, 5, 1, 0, 0, time.UTC)},
+		{name: "other day", now: time.Date(2026, time.January, 6, 5, 0, 0, 0, time.UTC)},
+		{name: "German time", now: time.Date(2026, time.January, 5, 6, 0, 0, 0, raidReminderLocation), want: true},
+	}
+
+	for _, tt := range tests {
+		if got := reminder.Matches(tt.now); got != tt.want {
+			t.Errorf("%s: Matches(%v) = %t, want %t", tt.name, tt.now, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// ClanWar is a war of a family clan followed by the war tracker.
+type ClanWar struct {
+	ID                   uint      `gorm:"primaryKey"`
+	ClanTag              string    `gorm:"size:12;not null;uniqueIndex:idx_clan_war"`
+	PreparationStartTime time.Time `gorm:"not null;uniqueIndex:idx_clan_war"`
+	StartTime            time.Time `gorm:"not null"`
+	EndTime              time.Time `gorm:"not null"`
+	OpponentTag          string    `gorm:"size:12;not null"`
+	OpponentName         string    `gorm:"size:50;not null"`
+	TeamSize             int       `gorm:"not null"`
+	State                string    `gorm:"size:20;not null"`
+	LineupPostedAt       *time.Time
+	DonorsPostedAt       *time.Time
+	LastReminderAt       *time.Time
+	ResultPostedAt       *time.Time
+	BoardMessageID       string `gorm:"size:19"`            // message of the call board in the war channel
+	ThreadID             string `gorm:"size:19"`            // thread of the war in the war thread channel
+	LastLoggedAttack     int    `gorm:"not null;default:0"` // order of the last attack posted in the thread
+
+	// set when the war has ended
+	Result              WarResult `gorm:"size:10"`
+	Stars               int       `gorm:"not null;default:0"`
+	Destruction         float64   `gorm:"not null;default:0"`
+	OpponentStars       int       `gorm:"not null;default:0"`
+	OpponentDestruction float64   `gorm:"not null;default:0"`
+
+	Clan         *Clan             `gorm:"foreignKey:Tag;references:ClanTag"`
+	Participants []*WarParticipant `gorm:"foreignKey:ClanWarID;constraint:OnDelete:CASCADE"`
+	Attacks      []*WarAttack      `gorm:"foreignKey:ClanWarID;constraint:OnDelete:CASCADE"`
+	Calls        []*WarCall        `gorm:"foreignKey:ClanWarID;constraint:OnDelete:CASCADE"`
+}
+
+// WarParticipant is a member of the family clan in the lineup of a finished war.
+type WarParticipant struct {
+	ClanWarID     uint   `gorm:"primaryKey"`
+	PlayerTag     string `gorm:"size:12;primaryKey;index"`
+	Name          string `gorm:"size:50;not null"`
+	TownHallLevel int    `gorm:"not null"`
+	MapPosition   int    `gorm:"not null"`
+	Attacks       int    `gorm:"not null"`
+}
+
+// WarAttack is an attack of a member of the family clan in a finished war.
+type WarAttack struct {
+	ID                    uint   `gorm:"primaryKey"`
+	ClanWarID             uint   `gorm:"not null;index"`
+	Order                 int    `gorm:"column:attack_order;not null"`
+	AttackerTag           string `gorm:"size:12;not null;index"`
+	AttackerTownHallLevel int    `gorm:"not null"`
+	DefenderTag           string `gorm:"size:12;not null"`
+	DefenderTownHallLevel int    `gorm:"not null"`
+	DefenderMapPosition   int    `gorm:"not null"`
+	Stars                 int    `gorm:"not null"`
+	Destruction           int    `gorm:"not null"`
+}
+
+// WarResult is the outcome of a war from the view of the family clan.
+type WarResult string
+
+const (
+	WarResultWin  WarResult = "win"
+	WarResultLose WarResult = "lose"
+	WarResultTie  WarResult = "tie"
+)
+
+func (r WarResult) Format() string {
+	switch r {
+	case WarResultWin:
+		return "Sieg"
+	case WarResultLose:
+		return "Niederlage"
+	case WarResultTie:
+		return "Unentschieden"
+	default:
+		return "Unbekannt"
+	}
+}
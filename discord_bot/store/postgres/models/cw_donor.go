@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// CWDonorAssignment is a member assigned to fill the war clan castles of a range of map positions.
+type CWDonorAssignment struct {
+	ID                   uint      `gorm:"primaryKey"`
+	ClanTag              string    `gorm:"size:12;not null;uniqueIndex:idx_cw_donor_assignment"`
+	PreparationStartTime time.Time `gorm:"not null;uniqueIndex:idx_cw_donor_assignment"`
+	RangeStart           int       `gorm:"not null;uniqueIndex:idx_cw_donor_assignment"`
+	RangeEnd             int       `gorm:"not null"`
+	PlayerTag            string    `gorm:"size:12;not null;index"`
+	AssignedAt           time.Time `gorm:"not null"`
+}
+
+// CWDonorOptOut marks a player who does not want to be assigned as war donor.
+type CWDonorOptOut struct {
+	PlayerTag string `gorm:"size:12;primaryKey"`
+	CreatedAt time.Time
+}
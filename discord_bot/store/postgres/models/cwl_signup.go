@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// CWLSignup is an account signed up for the CWL of a season (YYYY-MM). AssignedClanTag is set by the roster planning.
+type CWLSignup struct {
+	ID               uint    `gorm:"primaryKey"`
+	Season           string  `gorm:"size:7;not null;uniqueIndex:idx_cwl_signup"`
+	PlayerTag        string  `gorm:"size:12;not null;uniqueIndex:idx_cwl_signup"`
+	TownHallLevel    int     `gorm:"not null"`
+	PreferredClanTag *string `gorm:"size:12"` // chosen by the user, by default the clan the account was a member of when signing up
+	AssignedClanTag  *string `gorm:"size:12"`
+	CreatedAt        time.Time
+
+	Player *Player `gorm:"foreignKey:CocTag;references:PlayerTag"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// DonationSnapshot holds the latest donations of a member in a season. The values of a finished season are the ones taken right before the reset.
+type DonationSnapshot struct {
+	Season            string `gorm:"size:7;primaryKey"` // month in which the season ends, format YYYY-MM
+	PlayerTag         string `gorm:"size:12;primaryKey"`
+	ClanTag           string `gorm:"size:12;not null;index"` // clan of the member when the snapshot was taken
+	Name              string `gorm:"size:50;not null"`
+	Donations         int    `gorm:"not null"`
+	DonationsReceived int    `gorm:"not null"`
+	UpdatedAt         time.Time
+
+	Clan *Clan `gorm:"foreignKey:Tag;references:ClanTag"`
+}
+
+// Ratio returns the donations divided by the received donations. Members who received nothing have the ratio of their donations.
+func (s *DonationSnapshot) Ratio() float64 {
+	if s.DonationsReceived == 0 {
+		return float64(s.Donations)
+	}
+	return float64(s.Donations) / float64(s.DonationsReceived)
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// MemberHistory is a membership of a player in a family clan. LeftAt is nil while the player is still a member.
+type MemberHistory struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement;not null"`
+	PlayerTag  string     `gorm:"size:12;not null;index"`
+	ClanTag    string     `gorm:"size:12;not null"`
+	ClanRole   ClanRole   `gorm:"size:16"`
+	JoinedAt   *time.Time // nil if the player joined before the history was recorded
+	LeftAt     *time.Time
+	LeftReason LeftReason `gorm:"size:16"` // empty for memberships closed before the reason was recorded
+
+	Clan *Clan `gorm:"foreignKey:Tag;references:ClanTag"`
+}
+
+// LeftReason is why a membership was closed.
+type LeftReason string
+
+const (
+	LeftReasonRemoved     LeftReason = "removed"
+	LeftReasonTransferred LeftReason = "transferred"
+)
+
+// Removed reports whether the member was removed from the clan. Memberships without a recorded reason count as removals.
+func (h *MemberHistory) Removed() bool {
+	return h.LeftAt != nil && h.LeftReason != LeftReasonTransferred
+}
+
+// Covers reports whether the player was a member during the whole period.
+func (h *MemberHistory) Covers(start, end time.Time) bool {
+	return (h.JoinedAt == nil || !h.JoinedAt.After(start)) && (h.LeftAt == nil || !h.LeftAt.Before(end))
+}
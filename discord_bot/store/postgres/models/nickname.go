@@ -0,0 +1,29 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Nickname stores how the nickname of a Discord user is built, so it can be updated when the in-game name changes.
+type Nickname struct {
+	DiscordID     string `gorm:"size:19;primaryKey;not null"`
+	PlayerTag     string `gorm:"size:12"` // account whose name is used, empty if the user only opted out
+	Alias         string `gorm:"size:20"`
+	AppliedNick   string `gorm:"size:32"` // last nickname the bot set
+	SyncDisabled  bool   `gorm:"default:false;not null"`
+	FailedAt      *time.Time
+	FailureReason string `gorm:"size:100"`
+
+	Player *Player `gorm:"foreignKey:CocTag;references:PlayerTag"`
+}
+
+// Build returns the nickname made of the current player name and the alias.
+func (n *Nickname) Build(playerName string) string {
+	if n.Alias == "" {
+		return playerName
+	}
+	return fmt.Sprintf("%s | %s", playerName, n.Alias)
+}
+
+type Nicknames []*Nickname
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -11,6 +12,8 @@ type Player struct {
 	CocTag    string `gorm:"not null;primaryKey"`
 	Name      string `gorm:"not null"`
 	DiscordID string
+	// LeftServerAt is set while the linked Discord user is not on the server.
+	LeftServerAt *time.Time
 
 	Members ClanMembers `gorm:"foreignKey:PlayerTag;references:CocTag"`
 }
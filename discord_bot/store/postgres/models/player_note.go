@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// PlayerNote is a private note of a leader about a player.
+type PlayerNote struct {
+	ID                 uint           `gorm:"primaryKey;autoIncrement;not null"`
+	PlayerTag          string         `gorm:"size:12;not null;index"`
+	ClanTag            string         `gorm:"size:12;not null"`
+	Content            string         `gorm:"size:200;not null"`
+	Visibility         NoteVisibility `gorm:"size:16;not null"`
+	CreatedByDiscordID string         `gorm:"size:19;not null"`
+	CreatedAt          time.Time
+
+	Player        *Player `gorm:"foreignKey:CocTag;references:PlayerTag"`
+	Clan          *Clan   `gorm:"foreignKey:Tag;references:ClanTag"`
+	CreatedByUser *User   `gorm:"foreignKey:DiscordID;references:CreatedByDiscordID"`
+}
+
+type NoteVisibility string
+
+const (
+	NoteVisibilityCoLeader NoteVisibility = "coLeader" // only co-leaders of the clan the note was written in
+	NoteVisibilityFamily   NoteVisibility = "family"   // co-leaders of all family clans
+)
+
+func (v NoteVisibility) String() string {
+	return string(v)
+}
+
+func (v NoteVisibility) Format() string {
+	switch v {
+	case NoteVisibilityCoLeader:
+		return "Vize-Anführer des Clans"
+	case NoteVisibilityFamily:
+		return "Leader aller Family Clans"
+	default:
+		return "Unbekannte Sichtbarkeit"
+	}
+}
+
+type PlayerNotes []*PlayerNote
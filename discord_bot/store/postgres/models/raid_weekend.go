@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// RaidWeekend is a finished raid weekend of a family clan.
+type RaidWeekend struct {
+	ID                      uint      `gorm:"primaryKey"`
+	ClanTag                 string    `gorm:"size:12;not null;uniqueIndex:idx_raid_weekend"`
+	StartTime               time.Time `gorm:"not null;uniqueIndex:idx_raid_weekend"`
+	EndTime                 time.Time `gorm:"not null"`
+	CapitalTotalLoot        int       `gorm:"not null"`
+	RaidsCompleted          int       `gorm:"not null"`
+	TotalAttacks            int       `gorm:"not null"`
+	EnemyDistrictsDestroyed int       `gorm:"not null"`
+	OffensiveReward         int       `gorm:"not null"`
+	DefensiveReward         int       `gorm:"not null"`
+
+	Clan      *Clan           `gorm:"foreignKey:Tag;references:ClanTag"`
+	Members   []*RaidMember   `gorm:"foreignKey:RaidWeekendID;constraint:OnDelete:CASCADE"`
+	Districts []*RaidDistrict `gorm:"foreignKey:RaidWeekendID;constraint:OnDelete:CASCADE"`
+}
+
+// RaidMember is a member of the family clan who attacked during a raid weekend.
+type RaidMember struct {
+	RaidWeekendID          uint   `gorm:"primaryKey"`
+	PlayerTag              string `gorm:"size:12;primaryKey;index"`
+	Name                   string `gorm:"size:50;not null"`
+	Attacks                int    `gorm:"not null"`
+	AttackLimit            int    `gorm:"not null"` // including bonus attacks
+	CapitalResourcesLooted int    `gorm:"not null"`
+}
+
+// RaidDistrict is a district of an enemy capital attacked during a raid weekend.
+type RaidDistrict struct {
+	ID                uint   `gorm:"primaryKey"`
+	RaidWeekendID     uint   `gorm:"not null;index"`
+	DefenderTag       string `gorm:"size:12;not null"`
+	DefenderName      string `gorm:"size:50;not null"`
+	Name              string `gorm:"size:50;not null"`
+	DistrictHallLevel int    `gorm:"not null"`
+	Stars             int    `gorm:"not null"`
+	Destruction       int    `gorm:"not null"`
+	Attacks           int    `gorm:"not null"`
+	Looted            int    `gorm:"not null"`
+}
+
+// Destroyed reports whether the district was destroyed completely.
+func (d *RaidDistrict) Destroyed() bool {
+	return d.Destruction == 100
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// WarCall is a claim of a member on an enemy base. Only one unexpired call per base is possible.
+type WarCall struct {
+	ID                uint      `gorm:"primaryKey"`
+	ClanWarID         uint      `gorm:"not null;uniqueIndex:idx_war_call_target"`
+	TargetPosition    int       `gorm:"not null;uniqueIndex:idx_war_call_target"`
+	PlayerTag         string    `gorm:"size:12;not null"`
+	PlayerName        string    `gorm:"size:50;not null"`
+	CalledByDiscordID string    `gorm:"size:19;not null"`
+	ExpiresAt         time.Time `gorm:"not null"`
+	CreatedAt         time.Time
+}
@@ -0,0 +1,8 @@
+package goclash
+
+type ImageURLs struct {
+	Tiny   string `json:"tiny,omitempty"`
+	Small  string `json:"small,omitempty"`
+	Medium string `json:"medium,omitempty"`
+	Large  string `json:"large,omitempty"`
+}
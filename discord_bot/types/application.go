@@ -0,0 +1,7 @@
+package types
+
+// ApplicationAnswer is the answer of an applicant to one of the application questions.
+type ApplicationAnswer struct {
+	Question string
+	Answer   string
+}
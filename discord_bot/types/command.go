@@ -17,6 +17,7 @@ type InteractionHandler struct {
 	Main         func(s *discordgo.Session, i *discordgo.InteractionCreate)
 	Autocomplete func(s *discordgo.Session, i *discordgo.InteractionCreate)
 	ModalSubmit  func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Component    func(s *discordgo.Session, i *discordgo.InteractionCreate)
 }
 
 func (commands Commands[T]) ApplicationCommands() []*discordgo.ApplicationCommand {
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"math"
+	"time"
+)
+
+// CWDonorRange is a range of map positions whose war clan castles are filled by one donor.
+type CWDonorRange struct {
+	Start int
+	End   int
+}
+
+func (r CWDonorRange) Contains(mapPosition int) bool {
+	return mapPosition >= r.Start && mapPosition <= r.End
+}
+
+// CWDonorCandidate is a war member who can be assigned as donor.
+type CWDonorCandidate struct {
+	Tag               string
+	Name              string
+	DiscordID         string
+	MapPosition       int
+	RecentAssignments int // assignments in the clan within the lookback period
+	LastAssignedAt    *time.Time
+}
+
+// Weight returns the relative chance of the candidate to be selected. Every recent assignment lowers it considerably.
+func (c *CWDonorCandidate) Weight() float64 {
+	return 1 / math.Pow(float64(1+c.RecentAssignments), 2)
+}
+
+// CWDonor is a candidate selected as donor for a range.
+type CWDonor struct {
+	*CWDonorCandidate
+	Range    CWDonorRange
+	Chance   float64 // chance of the candidate at the time of the selection
+	OwnRange bool    // no candidate outside of the range was left
+}
@@ -0,0 +1,39 @@
+package types
+
+import "bot/store/postgres/models"
+
+// CWLRoster is the planned lineup of a family clan for a CWL season.
+type CWLRoster struct {
+	Clan    *models.Clan
+	Signups []*models.CWLSignup
+}
+
+// CWLMemberStats are the attack results of a clan member over the rounds of a CWL.
+type CWLMemberStats struct {
+	PlayerTag     string
+	Name          string
+	TownHallLevel int
+	Wars          int // rounds the member was in the lineup, counted once the battle day started
+	Attacks       int
+	Stars         int
+	Destruction   int // summed destruction percentage of all attacks
+	TownHallDiff  int // summed defender minus attacker town hall level of all attacks
+}
+
+// MissedAttacks returns the number of attacks the member did not use. Every member has one attack per CWL war.
+func (s *CWLMemberStats) MissedAttacks() int {
+	return s.Wars - s.Attacks
+}
+
+// CWLBonusWeights are the points a member gets for each part of the CWL bonus ranking.
+type CWLBonusWeights struct {
+	Star         int
+	Destruction  int // per 100% destruction
+	Attack       int
+	TownHallDiff int // per town hall level the defender is above the attacker, negative below
+}
+
+// Score returns the points of the member in the CWL bonus ranking.
+func (w CWLBonusWeights) Score(s *CWLMemberStats) int {
+	return s.Stars*w.Star + s.Destruction*w.Destruction/100 + s.Attacks*w.Attack + s.TownHallDiff*w.TownHallDiff
+}
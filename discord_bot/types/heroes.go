@@ -0,0 +1,27 @@
+package types
+
+// heroMaxLevels contains the maximum level of each home village hero per town hall level.
+var heroMaxLevels = map[string]map[int]int{
+	"Barbarian King": {7: 10, 8: 20, 9: 30, 10: 40, 11: 50, 12: 65, 13: 75, 14: 80, 15: 90, 16: 95, 17: 100},
+	"Archer Queen":   {9: 30, 10: 40, 11: 50, 12: 65, 13: 75, 14: 80, 15: 90, 16: 95, 17: 100},
+	"Minion Prince":  {9: 10, 10: 20, 11: 30, 12: 40, 13: 50, 14: 60, 15: 70, 16: 80, 17: 90},
+	"Grand Warden":   {11: 20, 12: 40, 13: 50, 14: 55, 15: 65, 16: 70, 17: 75},
+	"Royal Champion": {13: 25, 14: 30, 15: 40, 16: 45, 17: 50},
+}
+
+// HeroMaxLevel returns the maximum level of the hero at the given town hall level, or 0 if the hero is not unlocked yet or unknown.
+func HeroMaxLevel(heroName string, townHallLevel int) int {
+	levels, ok := heroMaxLevels[heroName]
+	if !ok {
+		return 0
+	}
+
+	// town halls above the highest known level use the highest known maximum
+	maxLevel := 0
+	for th, level := range levels {
+		if th <= townHallLevel && level > maxLevel {
+			maxLevel = level
+		}
+	}
+	return maxLevel
+}
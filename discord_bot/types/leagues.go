@@ -0,0 +1,58 @@
+package types
+
+import (
+	"github.com/aaantiii/goclash"
+	"github.com/bwmarrin/discordgo"
+)
+
+type League struct {
+	ID   int
+	Name string
+}
+
+// HomeLeagues are the ranked leagues of the home village, ordered from lowest to highest.
+var HomeLeagues = []*League{
+	{ID: goclash.LeagueBronzeIII, Name: "Bronze III"},
+	{ID: goclash.LeagueBronzeII, Name: "Bronze II"},
+	{ID: goclash.LeagueBronzeI, Name: "Bronze I"},
+	{ID: goclash.LeagueSilverIII, Name: "Silber III"},
+	{ID: goclash.LeagueSilverII, Name: "Silber II"},
+	{ID: goclash.LeagueSilverI, Name: "Silber I"},
+	{ID: goclash.LeagueGoldIII, Name: "Gold III"},
+	{ID: goclash.LeagueGoldII, Name: "Gold II"},
+	{ID: goclash.LeagueGoldI, Name: "Gold I"},
+	{ID: goclash.LeagueCrystalIII, Name: "Kristall III"},
+	{ID: goclash.LeagueCrystalII, Name: "Kristall II"},
+	{ID: goclash.LeagueCrystalI, Name: "Kristall I"},
+	{ID: goclash.LeagueMasterIII, Name: "Meister III"},
+	{ID: goclash.LeagueMasterII, Name: "Meister II"},
+	{ID: goclash.LeagueMasterI, Name: "Meister I"},
+	{ID: goclash.LeagueChampionIII, Name: "Champion III"},
+	{ID: goclash.LeagueChampionII, Name: "Champion II"},
+	{ID: goclash.LeagueChampionI, Name: "Champion I"},
+	{ID: goclash.LeagueTitanIII, Name: "Titan III"},
+	{ID: goclash.LeagueTitanII, Name: "Titan II"},
+	{ID: goclash.LeagueTitanI, Name: "Titan I"},
+	{ID: goclash.LeagueLegend, Name: "Legende"},
+}
+
+// HomeLeagueName returns the name of the home village league with the given id.
+func HomeLeagueName(id int) string {
+	for _, league := range HomeLeagues {
+		if league.ID == id {
+			return league.Name
+		}
+	}
+	return "Ungewertet"
+}
+
+func HomeLeagueChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(HomeLeagues))
+	for i, league := range HomeLeagues {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  league.Name,
+			Value: league.ID,
+		}
+	}
+	return choices
+}
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"github.com/aaantiii/goclash"
+
+	"bot/store/postgres/models"
+)
+
+// PlayerProfile bundles the stored and the live data of a linked account.
+type PlayerProfile struct {
+	Player           *models.Player
+	ClashPlayer      *goclash.Player // nil if the player could not be fetched from the API
+	ActiveKickpoints int
+	LockedClanTags   []string // clans in which the player is locked for kickpoints
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"time"
+
+	"github.com/aaantiii/goclash"
+
+	"bot/store/postgres/models"
+)
+
+// PromotionCandidate is a clan member who is checked against the promotion criteria of the clan.
+type PromotionCandidate struct {
+	Member       *models.ClanMember
+	ClashPlayer  *goclash.Player // nil if the player could not be fetched from the API
+	JoinedAt     *time.Time      // nil if the member joined before the history was recorded
+	Kickpoints   int             // all kickpoints, including expired ones
+	WarAttacks   *WarAttackUsage // nil if the member was not in the lineup of the checked wars
+	Requirements []*Requirement
+}
+
+func (c *PromotionCandidate) MetCount() int {
+	var met int
+	for _, requirement := range c.Requirements {
+		if requirement.Met {
+			met++
+		}
+	}
+	return met
+}
+
+// Suggested reports whether the candidate meets all criteria.
+func (c *PromotionCandidate) Suggested() bool {
+	return c.MetCount() == len(c.Requirements)
+}
+
+// WarAttackUsage is the number of war attacks a member used out of the available ones.
+type WarAttackUsage struct {
+	Used      int
+	Available int
+}
+
+// Rate returns the share of the available attacks the member used.
+func (u *WarAttackUsage) Rate() float64 {
+	if u.Available == 0 {
+		return 0
+	}
+	return float64(u.Used) / float64(u.Available)
+}
@@ -0,0 +1,20 @@
+package types
+
+// RaidPlayerStats are the raid results of a player over several raid weekends.
+type RaidPlayerStats struct {
+	PlayerTag        string
+	Name             string
+	Weekends         int // weekends the player attacked in or was a member for without attacking
+	CompleteWeekends int // weekends in which the player used all attacks
+	Attacks          int
+	AttackLimit      int
+	Looted           int
+}
+
+// AttackRate returns the share of the available attacks the player used.
+func (s *RaidPlayerStats) AttackRate() float64 {
+	if s.AttackLimit == 0 {
+		return 0
+	}
+	return float64(s.Attacks) / float64(s.AttackLimit)
+}
@@ -0,0 +1,15 @@
+package types
+
+import "bot/store/postgres/models"
+
+// RejoinHistory is everything leaders should know before a former member is added again.
+type RejoinHistory struct {
+	Removals       []*models.MemberHistory // memberships closed by a removal, most recent first
+	Kickpoints     []*models.Kickpoint     // all kickpoints, including expired ones
+	KickpointSum   int
+	BlacklistEntry *models.BlacklistEntry // active or expired
+}
+
+func (h *RejoinHistory) Empty() bool {
+	return len(h.Removals) == 0 && h.KickpointSum == 0 && h.BlacklistEntry == nil
+}
@@ -0,0 +1,9 @@
+package types
+
+// Requirement is a clan requirement that was checked for a player.
+type Requirement struct {
+	Name     string
+	Required string
+	Actual   string
+	Met      bool
+}
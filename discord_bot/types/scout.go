@@ -0,0 +1,37 @@
+package types
+
+import (
+	"github.com/aaantiii/goclash"
+
+	"bot/store/postgres/models"
+)
+
+// ScoutedHero is a home village hero compared with the maximum levels of the player's town hall.
+type ScoutedHero struct {
+	Name      string
+	Level     int
+	MaxLevel  int // maximum at the current town hall
+	RushLevel int // maximum at the previous town hall, heroes below it count as rushed
+}
+
+func (h *ScoutedHero) Rushed() bool {
+	return h.Level < h.RushLevel
+}
+
+// PlayerScout bundles the live data and the family history of a player.
+type PlayerScout struct {
+	ClashPlayer *goclash.Player
+	Heroes      []*ScoutedHero
+	History     []*models.MemberHistory
+	Kickpoints  []*models.Kickpoint // all kickpoints, including expired ones
+}
+
+// Rushed reports whether any hero of the player is below the maximum of the previous town hall.
+func (p *PlayerScout) Rushed() bool {
+	for _, hero := range p.Heroes {
+		if hero.Rushed() {
+			return true
+		}
+	}
+	return false
+}
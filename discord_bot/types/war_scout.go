@@ -0,0 +1,26 @@
+package types
+
+// WarScoutPlayer is a member of a war lineup together with the live data of the account.
+type WarScoutPlayer struct {
+	Tag           string
+	Name          string
+	MapPosition   int
+	TownHallLevel int
+	HeroLevels    int // sum of the home village hero levels
+	WarStars      int
+	LiveData      bool // false if the account could not be loaded, HeroLevels and WarStars are unknown then
+}
+
+// WarScoutMatchup compares an opponent with the member at the same map position.
+type WarScoutMatchup struct {
+	Member   *WarScoutPlayer
+	Opponent *WarScoutPlayer
+	Threat   float64 // the higher, the stronger the opponent is compared to the member
+}
+
+// WarScout compares both lineups of a war.
+type WarScout struct {
+	Clan     []*WarScoutPlayer
+	Opponent []*WarScoutPlayer
+	Matchups []*WarScoutMatchup // sorted by threat, most dangerous first. Players without live data have no matchup.
+}
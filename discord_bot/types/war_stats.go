@@ -0,0 +1,40 @@
+package types
+
+// WarPlayerStats are the attack statistics of a player over several finished wars.
+type WarPlayerStats struct {
+	PlayerTag      string
+	Name           string
+	Wars           int
+	Attacks        int
+	MissedAttacks  int
+	Stars          int
+	ThreeStars     int
+	ByTownHallDiff map[int]*WarStarAverage // by defender minus attacker town hall level
+}
+
+// HitRate returns the share of attacks with three stars.
+func (s *WarPlayerStats) HitRate() float64 {
+	if s.Attacks == 0 {
+		return 0
+	}
+	return float64(s.ThreeStars) / float64(s.Attacks)
+}
+
+func (s *WarPlayerStats) AverageStars() float64 {
+	if s.Attacks == 0 {
+		return 0
+	}
+	return float64(s.Stars) / float64(s.Attacks)
+}
+
+type WarStarAverage struct {
+	Attacks int
+	Stars   int
+}
+
+func (a *WarStarAverage) Average() float64 {
+	if a.Attacks == 0 {
+		return 0
+	}
+	return float64(a.Stars) / float64(a.Attacks)
+}
@@ -46,7 +46,16 @@ func newUpdatePlayersTaskFactory(db *gorm.DB, client *goclash.Client) func() Tas
 				slog.Info("No player names to update.")
 				return nil
 			}
-			if err := db.Save(changes).Error; err != nil {
+			// only the name is written, the cached players may be outdated otherwise, e.g. after a relink by the bot
+			err := db.Transaction(func(tx *gorm.DB) error {
+				for _, player := range changes {
+					if err := tx.Model(&models.Player{}).Where("coc_tag = ?", player.CocTag).Update("name", player.Name).Error; err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
 				return err
 			}
 